@@ -3,7 +3,6 @@ package bump
 import (
 	"fmt"
 	"os"
-	"strings"
 
 	"github.com/dp1140a/semver/cmd"
 	"github.com/dp1140a/semver/pkg/cli"
@@ -17,6 +16,8 @@ const (
 	bumpPatch bumpKind = iota
 	bumpMinor
 	bumpMajor
+	bumpPre
+	bumpRevision
 )
 
 var BumpCmd = &cobra.Command{
@@ -38,10 +39,21 @@ func init() {
 		"Show what the next version would be; do not write VERSION",
 	)
 
+	addPreFlag(BumpCmd)
+
 	// Subcommands using the same runner
-	BumpCmd.AddCommand(newBumpSubCmd("patch", "Bump patch version", bumpPatch))
-	BumpCmd.AddCommand(newBumpSubCmd("minor", "Bump minor version", bumpMinor))
-	BumpCmd.AddCommand(newBumpSubCmd("major", "Bump major version", bumpMajor))
+	BumpCmd.AddCommand(addPreFlag(newBumpSubCmd("patch", "Bump patch version", bumpPatch)))
+	BumpCmd.AddCommand(addPreFlag(newBumpSubCmd("minor", "Bump minor version", bumpMinor)))
+	BumpCmd.AddCommand(addPreFlag(newBumpSubCmd("major", "Bump major version", bumpMajor)))
+
+	preCmd := newBumpSubCmd("pre", "Bump prerelease version (e.g., rc.1 -> rc.2)", bumpPre)
+	preCmd.Flags().String(
+		"preid", "",
+		"Prerelease identifier to use (e.g., rc); switching identifiers restarts at 0",
+	)
+	BumpCmd.AddCommand(preCmd)
+
+	BumpCmd.AddCommand(newBumpSubCmd("revision", "Bump revision of a four-segment version (e.g., 1.2.3.4 -> 1.2.3.5)", bumpRevision))
 }
 
 func newBumpSubCmd(name, desc string, kind bumpKind) *cobra.Command {
@@ -54,6 +66,16 @@ func newBumpSubCmd(name, desc string, kind bumpKind) *cobra.Command {
 	}
 }
 
+// addPreFlag adds --pre to a core bump so it can start a prerelease series
+// in the same step (e.g., bump minor --pre beta: 1.2.3 -> 1.3.0-beta.0).
+func addPreFlag(c *cobra.Command) *cobra.Command {
+	c.Flags().String(
+		"pre", "",
+		"Start a prerelease series with this identifier after bumping (e.g., beta)",
+	)
+	return c
+}
+
 func runBump(cmd *cobra.Command, kind bumpKind) error {
 	dry, _ := cmd.Flags().GetBool("dry")
 
@@ -69,28 +91,45 @@ func runBump(cmd *cobra.Command, kind bumpKind) error {
 
 	fmt.Printf("Current Version: %s\n", cur)
 
-	v := types.NewVersionFromString(strings.TrimSpace(cur))
+	if f, err := types.ParseFourPart(cur); err == nil {
+		return bumpFourPart(cmd, f, kind, dry)
+	}
+	if kind == bumpRevision {
+		return fmt.Errorf("bump revision needs a four-segment version such as 1.2.3.4, not %s", cur)
+	}
+
+	v, err := types.Parse(cur)
+	if err != nil {
+		return err
+	}
 
 	switch kind {
 	case bumpPatch:
 		fmt.Println("Bumping Patch")
-		v.IncrementPatch()
+		v, err = v.NextPatch()
 	case bumpMinor:
 		fmt.Println("Bumping Minor")
-		v.IncrementMinor()
+		v, err = v.NextMinor()
 	case bumpMajor:
 		fmt.Println("Bumping Major")
-		v.IncrementMajor()
+		v, err = v.NextMajor()
+	case bumpPre:
+		preid, _ := cmd.Flags().GetString("preid")
+		fmt.Println("Bumping PreRelease")
+		v, err = v.NextPre(preid)
 	default:
 		return fmt.Errorf("unknown bump kind: %v", kind)
 	}
+	if err != nil {
+		return err
+	}
 
-	next := v.String()
-	if dry {
-		cli.RenderDry(next)
-		return nil
+	if pre, _ := cmd.Flags().GetString("pre"); pre != "" {
+		fmt.Printf("Starting PreRelease %s.0\n", pre)
+		if v, err = v.WithPre(pre + ".0"); err != nil {
+			return err
+		}
 	}
 
-	fmt.Printf("New Version: %s\n", next)
-	return cli.WriteVersion(next)
+	return cli.ApplyVersion(v.String(), dry)
 }
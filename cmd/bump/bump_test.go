@@ -1,15 +1,15 @@
 package bump
 
 import (
-	"bytes"
-
-	"io"
+	"errors"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
 
-	"github.com/dp1140a/semver/cmd"
+	"github.com/dp1140a/semver/cmd/internal/cmdtest"
+	"github.com/dp1140a/semver/pkg/cli"
+	"github.com/dp1140a/semver/pkg/store"
 	"github.com/dp1140a/semver/pkg/util"
 )
 
@@ -35,70 +35,24 @@ func withPatchedExit(t *testing.T, fn func()) (code int) {
 	return 0 // unreachable if command exits, but keeps signature tidy
 }
 
-func withTempWD(t *testing.T, f func(tmp string)) {
-	t.Helper()
-	orig, err := os.Getwd()
-	if err != nil {
-		t.Fatalf("getwd: %v", err)
-	}
-	t.Cleanup(func() { _ = os.Chdir(orig) })
-
-	tmp := t.TempDir()
-	if err := os.Chdir(tmp); err != nil {
-		t.Fatalf("chdir temp: %v", err)
-	}
-	f(tmp)
-}
-
-func writeVERSION(t *testing.T, v string) {
-	t.Helper()
-	if err := os.WriteFile("VERSION", []byte(v+"\n"), 0o644); err != nil {
-		t.Fatalf("write VERSION: %v", err)
-	}
-}
-
-func readVERSION(t *testing.T) string {
-	t.Helper()
-	b, err := os.ReadFile("VERSION")
-	if err != nil {
-		t.Fatalf("read VERSION: %v", err)
-	}
-	return strings.TrimSpace(string(b))
-}
-
-// captureStdout runs fn while capturing stdout
-func captureStdout(t *testing.T, fn func()) string {
-	t.Helper()
-	orig := os.Stdout
-	r, w, _ := os.Pipe()
-	os.Stdout = w
-	defer func() { os.Stdout = orig }()
-	fn()
-	_ = w.Close()
-	var buf bytes.Buffer
-	_, _ = io.Copy(&buf, r)
-	return buf.String()
-}
-
 func TestBumpPatch_WritesAndPrints(t *testing.T) {
-	withTempWD(t, func(tmp string) {
-		writeVERSION(t, "1.2.3")
+	cmdtest.WithTempWD(t, func(tmp string) {
+		cmdtest.WriteVERSION(t, "1.2.3")
 		// sanity: util functions see the VERSION file
 		if !util.VersionFileExists(tmp) {
 			t.Fatalf("expected VERSION to exist")
 		}
 
-		out := captureStdout(t, func() {
+		out := cmdtest.CaptureStdout(t, func() {
 			code := withPatchedExit(t, func() {
-				cmd.RootCmd.SetArgs([]string{"bump", "patch"})
-				_ = cmd.RootCmd.Execute()
+				_ = cmdtest.Execute("bump", "patch")
 			})
 			if code != 0 {
 				t.Fatalf("unexpected exit code: %d", code)
 			}
 		})
 
-		got := readVERSION(t)
+		got := cmdtest.ReadVERSION(t)
 		if got != "1.2.4" {
 			t.Fatalf("expected VERSION=1.2.4, got %q", got)
 		}
@@ -109,18 +63,17 @@ func TestBumpPatch_WritesAndPrints(t *testing.T) {
 }
 
 func TestBumpMinor_WritesAndPrints(t *testing.T) {
-	withTempWD(t, func(tmp string) {
-		writeVERSION(t, "1.2.3")
-		out := captureStdout(t, func() {
+	cmdtest.WithTempWD(t, func(tmp string) {
+		cmdtest.WriteVERSION(t, "1.2.3")
+		out := cmdtest.CaptureStdout(t, func() {
 			code := withPatchedExit(t, func() {
-				cmd.RootCmd.SetArgs([]string{"bump", "minor"})
-				_ = cmd.RootCmd.Execute()
+				_ = cmdtest.Execute("bump", "minor")
 			})
 			if code != 0 {
 				t.Fatalf("unexpected exit code: %d", code)
 			}
 		})
-		if got := readVERSION(t); got != "1.3.0" {
+		if got := cmdtest.ReadVERSION(t); got != "1.3.0" {
 			t.Fatalf("expected VERSION=1.3.0, got %q", got)
 		}
 		if !strings.Contains(out, "Bumping Minor") || !strings.Contains(out, "New Version: 1.3.0") {
@@ -130,18 +83,17 @@ func TestBumpMinor_WritesAndPrints(t *testing.T) {
 }
 
 func TestBumpMajor_WritesAndPrints(t *testing.T) {
-	withTempWD(t, func(tmp string) {
-		writeVERSION(t, "1.2.3")
-		out := captureStdout(t, func() {
+	cmdtest.WithTempWD(t, func(tmp string) {
+		cmdtest.WriteVERSION(t, "1.2.3")
+		out := cmdtest.CaptureStdout(t, func() {
 			code := withPatchedExit(t, func() {
-				cmd.RootCmd.SetArgs([]string{"bump", "major"})
-				_ = cmd.RootCmd.Execute()
+				_ = cmdtest.Execute("bump", "major")
 			})
 			if code != 0 {
 				t.Fatalf("unexpected exit code: %d", code)
 			}
 		})
-		if got := readVERSION(t); got != "2.0.0" {
+		if got := cmdtest.ReadVERSION(t); got != "2.0.0" {
 			t.Fatalf("expected VERSION=2.0.0, got %q", got)
 		}
 		if !strings.Contains(out, "Bumping Major") || !strings.Contains(out, "New Version: 2.0.0") {
@@ -151,18 +103,17 @@ func TestBumpMajor_WritesAndPrints(t *testing.T) {
 }
 
 func TestBump_DefaultIsPatch(t *testing.T) {
-	withTempWD(t, func(tmp string) {
-		writeVERSION(t, "0.0.9")
-		out := captureStdout(t, func() {
+	cmdtest.WithTempWD(t, func(tmp string) {
+		cmdtest.WriteVERSION(t, "0.0.9")
+		out := cmdtest.CaptureStdout(t, func() {
 			code := withPatchedExit(t, func() {
-				cmd.RootCmd.SetArgs([]string{"bump"}) // no subcommand
-				_ = cmd.RootCmd.Execute()
+				_ = cmdtest.Execute("bump") // no subcommand
 			})
 			if code != 0 {
 				t.Fatalf("unexpected exit code: %d", code)
 			}
 		})
-		if got := readVERSION(t); got != "0.0.10" {
+		if got := cmdtest.ReadVERSION(t); got != "0.0.10" {
 			t.Fatalf("expected VERSION=0.0.10, got %q", got)
 		}
 		if !strings.Contains(out, "Bumping Patch") || !strings.Contains(out, "New Version: 0.0.10") {
@@ -172,18 +123,17 @@ func TestBump_DefaultIsPatch(t *testing.T) {
 }
 
 func TestBump_DryRunDoesNotWrite(t *testing.T) {
-	withTempWD(t, func(tmp string) {
-		writeVERSION(t, "1.2.3")
+	cmdtest.WithTempWD(t, func(tmp string) {
+		cmdtest.WriteVERSION(t, "1.2.3")
 
-		out := captureStdout(t, func() {
-			cmd.RootCmd.SetArgs([]string{"bump", "--dry", "minor"})
-			if err := cmd.RootCmd.Execute(); err != nil {
+		out := cmdtest.CaptureStdout(t, func() {
+			if err := cmdtest.Execute("bump", "--dry", "minor"); err != nil {
 				t.Fatalf("execute: %v", err)
 			}
 		})
 
 		// VERSION should remain unchanged on dry-run
-		if got := readVERSION(t); got != "1.2.3" {
+		if got := cmdtest.ReadVERSION(t); got != "1.2.3" {
 			t.Fatalf("expected VERSION unchanged on dry-run, got %q", got)
 		}
 
@@ -204,15 +154,14 @@ func TestBump_DryRunDoesNotWrite(t *testing.T) {
 }
 
 func TestBump_NoVersionFile_GracefulMessage(t *testing.T) {
-	withTempWD(t, func(tmp string) {
+	cmdtest.WithTempWD(t, func(tmp string) {
 		// Ensure no VERSION file
 		if _, err := os.Stat(filepath.Join(tmp, "VERSION")); !os.IsNotExist(err) {
 			t.Fatalf("expected no VERSION file")
 		}
-		out := captureStdout(t, func() {
+		out := cmdtest.CaptureStdout(t, func() {
 			code := withPatchedExit(t, func() {
-				cmd.RootCmd.SetArgs([]string{"bump", "patch"})
-				_ = cmd.RootCmd.Execute()
+				_ = cmdtest.Execute("bump", "patch")
 			})
 			// current code path prints and exits 0 (it’s fine to assert 0 here)
 			if code != 0 {
@@ -224,3 +173,436 @@ func TestBump_NoVersionFile_GracefulMessage(t *testing.T) {
 		}
 	})
 }
+
+func TestBump_RejectsMalformedVersionFile(t *testing.T) {
+	cmdtest.WithTempWD(t, func(tmp string) {
+		cmdtest.WriteVERSION(t, "garbage")
+		var err error
+		cmdtest.CaptureStdout(t, func() {
+			err = cmdtest.Execute("bump", "patch")
+		})
+		if err == nil {
+			t.Fatalf("expected error for malformed VERSION")
+		}
+		if got := cmdtest.ReadVERSION(t); got != "garbage" {
+			t.Fatalf("expected VERSION unchanged, got %q", got)
+		}
+	})
+}
+
+func TestBumpPre_IncrementsSeries(t *testing.T) {
+	cmdtest.WithTempWD(t, func(tmp string) {
+		cmdtest.WriteVERSION(t, "1.4.0-rc.1")
+		out := cmdtest.CaptureStdout(t, func() {
+			if err := cmdtest.Execute("bump", "pre"); err != nil {
+				t.Fatalf("execute: %v", err)
+			}
+		})
+		if got := cmdtest.ReadVERSION(t); got != "1.4.0-rc.2" {
+			t.Fatalf("expected VERSION=1.4.0-rc.2, got %q", got)
+		}
+		if !strings.Contains(out, "Bumping PreRelease") || !strings.Contains(out, "New Version: 1.4.0-rc.2") {
+			t.Fatalf("stdout missing expected lines:\n%s", out)
+		}
+	})
+}
+
+func TestBumpPre_PreidStartsSeries(t *testing.T) {
+	tests := []struct {
+		cur, preid, want string
+	}{
+		{"1.4.0", "rc", "1.4.1-rc.0"},
+		{"1.4.0-beta.3", "rc", "1.4.0-rc.0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.cur, func(t *testing.T) {
+			cmdtest.WithTempWD(t, func(tmp string) {
+				cmdtest.WriteVERSION(t, tt.cur)
+				cmdtest.CaptureStdout(t, func() {
+					if err := cmdtest.Execute("bump", "pre", "--preid", tt.preid); err != nil {
+						t.Fatalf("execute: %v", err)
+					}
+				})
+				if got := cmdtest.ReadVERSION(t); got != tt.want {
+					t.Fatalf("expected VERSION=%s, got %q", tt.want, got)
+				}
+			})
+		})
+	}
+}
+
+func TestBumpPre_DryRunDoesNotWrite(t *testing.T) {
+	cmdtest.WithTempWD(t, func(tmp string) {
+		cmdtest.WriteVERSION(t, "2.0.0-rc.3")
+		out := cmdtest.CaptureStdout(t, func() {
+			if err := cmdtest.Execute("bump", "--dry", "pre"); err != nil {
+				t.Fatalf("execute: %v", err)
+			}
+		})
+		if got := cmdtest.ReadVERSION(t); got != "2.0.0-rc.3" {
+			t.Fatalf("expected VERSION unchanged on dry-run, got %q", got)
+		}
+		if !strings.Contains(out, "New Version would be: 2.0.0-rc.4") {
+			t.Fatalf("stdout missing would-be version:\n%s", out)
+		}
+	})
+}
+
+func TestBumpWithPre_StartsSeries(t *testing.T) {
+	tests := []struct {
+		sub, want string
+	}{
+		{"major", "2.0.0-beta.0"},
+		{"minor", "1.3.0-beta.0"},
+		{"patch", "1.2.4-beta.0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.sub, func(t *testing.T) {
+			cmdtest.WithTempWD(t, func(tmp string) {
+				cmdtest.WriteVERSION(t, "1.2.3+build.1")
+				out := cmdtest.CaptureStdout(t, func() {
+					if err := cmdtest.Execute("bump", tt.sub, "--pre", "beta"); err != nil {
+						t.Fatalf("execute: %v", err)
+					}
+				})
+				if got := cmdtest.ReadVERSION(t); got != tt.want {
+					t.Fatalf("expected VERSION=%s, got %q", tt.want, got)
+				}
+				if !strings.Contains(out, "New Version: "+tt.want) {
+					t.Fatalf("stdout missing expected lines:\n%s", out)
+				}
+			})
+		})
+	}
+}
+
+func TestBumpWithPre_DefaultIsPatch(t *testing.T) {
+	cmdtest.WithTempWD(t, func(tmp string) {
+		cmdtest.WriteVERSION(t, "1.2.3")
+		cmdtest.CaptureStdout(t, func() {
+			if err := cmdtest.Execute("bump", "--pre", "rc"); err != nil {
+				t.Fatalf("execute: %v", err)
+			}
+		})
+		if got := cmdtest.ReadVERSION(t); got != "1.2.4-rc.0" {
+			t.Fatalf("expected VERSION=1.2.4-rc.0, got %q", got)
+		}
+	})
+}
+
+func TestBumpWithPre_InvalidIdentifier(t *testing.T) {
+	cmdtest.WithTempWD(t, func(tmp string) {
+		cmdtest.WriteVERSION(t, "1.2.3")
+		var err error
+		cmdtest.CaptureStdout(t, func() {
+			err = cmdtest.Execute("bump", "minor", "--pre", "be_ta")
+		})
+		if err == nil {
+			t.Fatalf("expected error for invalid prerelease identifier")
+		}
+		if got := cmdtest.ReadVERSION(t); got != "1.2.3" {
+			t.Fatalf("expected VERSION unchanged, got %q", got)
+		}
+	})
+}
+
+func TestBump_FourPart(t *testing.T) {
+	tests := []struct {
+		sub, want string
+	}{
+		{"revision", "1.2.3.5"},
+		{"patch", "1.2.4.0"},
+		{"minor", "1.3.0.0"},
+		{"major", "2.0.0.0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.sub, func(t *testing.T) {
+			cmdtest.WithTempWD(t, func(tmp string) {
+				cmdtest.WriteVERSION(t, "1.2.3.4")
+				out := cmdtest.CaptureStdout(t, func() {
+					if err := cmdtest.Execute("bump", tt.sub); err != nil {
+						t.Fatalf("execute: %v", err)
+					}
+				})
+				if got := cmdtest.ReadVERSION(t); got != tt.want {
+					t.Fatalf("expected VERSION=%s, got %q", tt.want, got)
+				}
+				if !strings.Contains(out, "Current Version: 1.2.3.4") || !strings.Contains(out, "New Version: "+tt.want) {
+					t.Fatalf("stdout missing expected lines:\n%s", out)
+				}
+			})
+		})
+	}
+}
+
+func TestBump_FourPartRejectsPrerelease(t *testing.T) {
+	cmdtest.WithTempWD(t, func(tmp string) {
+		cmdtest.WriteVERSION(t, "1.2.3.4")
+		for _, args := range [][]string{
+			{"bump", "pre"},
+			{"bump", "minor", "--pre", "rc"},
+		} {
+			var err error
+			cmdtest.CaptureStdout(t, func() {
+				err = cmdtest.Execute(args...)
+			})
+			if err == nil {
+				t.Fatalf("%v: expected an error", args)
+			}
+		}
+		if got := cmdtest.ReadVERSION(t); got != "1.2.3.4" {
+			t.Fatalf("expected VERSION unchanged, got %q", got)
+		}
+	})
+}
+
+func TestBumpRevision_RequiresFourPart(t *testing.T) {
+	cmdtest.WithTempWD(t, func(tmp string) {
+		cmdtest.WriteVERSION(t, "1.2.3")
+		var err error
+		cmdtest.CaptureStdout(t, func() {
+			err = cmdtest.Execute("bump", "revision")
+		})
+		if err == nil || !strings.Contains(err.Error(), "four-segment") {
+			t.Fatalf("expected a four-segment error, got %v", err)
+		}
+		if got := cmdtest.ReadVERSION(t); got != "1.2.3" {
+			t.Fatalf("expected VERSION unchanged, got %q", got)
+		}
+	})
+}
+
+// runBumpWith executes "bump minor" with args prepended.
+func runBumpWith(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	return cmdtest.Run(t, "", append(args, "bump", "minor")...)
+}
+
+func TestBump_FileAndDirFlags(t *testing.T) {
+	cmdtest.WithTempWD(t, func(tmp string) {
+		cmdtest.WriteVERSION(t, "9.9.9")
+		other := filepath.Join(tmp, "other")
+		if err := os.MkdirAll(other, 0o755); err != nil {
+			t.Fatal(err)
+		}
+		custom := filepath.Join(other, "RELEASE")
+		if err := os.WriteFile(custom, []byte("1.2.3\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(other, "VERSION"), []byte("2.0.0\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		for _, tt := range []struct {
+			args       []string
+			path, want string
+		}{
+			{[]string{"--file", custom}, custom, "1.3.0"},
+			{[]string{"--dir", other, "--file", "RELEASE"}, custom, "1.4.0"},
+			{[]string{"--dir", other}, filepath.Join(other, "VERSION"), "2.1.0"},
+		} {
+			if _, err := runBumpWith(t, tt.args...); err != nil {
+				t.Fatalf("%v: %v", tt.args, err)
+			}
+			if b, _ := os.ReadFile(tt.path); strings.TrimSpace(string(b)) != tt.want {
+				t.Fatalf("%v: %s holds %q, want %s", tt.args, tt.path, b, tt.want)
+			}
+		}
+
+		t.Setenv("SEMVER_FILE", custom)
+		if _, err := runBumpWith(t, "--file="); err != nil {
+			t.Fatalf("SEMVER_FILE: %v", err)
+		}
+		if b, _ := os.ReadFile(custom); strings.TrimSpace(string(b)) != "1.5.0" {
+			t.Fatalf("SEMVER_FILE: %s holds %q", custom, b)
+		}
+		if got := cmdtest.ReadVERSION(t); got != "9.9.9" {
+			t.Fatalf("./VERSION should be untouched, got %q", got)
+		}
+	})
+}
+
+func TestBump_DiscoversVersionUpToGitRoot(t *testing.T) {
+	cmdtest.WithTempWD(t, func(tmp string) {
+		cmdtest.WriteVERSION(t, "1.2.3")
+		if err := os.MkdirAll(filepath.Join(tmp, ".git"), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		sub := filepath.Join(tmp, "pkg", "deep")
+		if err := os.MkdirAll(sub, 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chdir(sub); err != nil {
+			t.Fatal(err)
+		}
+
+		var err error
+		stderr := cmdtest.CaptureStderr(t, func() {
+			_, err = runBumpWith(t)
+		})
+		if err != nil {
+			t.Fatalf("execute: %v", err)
+		}
+
+		path := filepath.Join(tmp, "VERSION")
+		if b, _ := os.ReadFile(path); strings.TrimSpace(string(b)) != "1.3.0" {
+			t.Fatalf("%s holds %q, want 1.3.0", path, b)
+		}
+		if _, err := os.Stat(filepath.Join(sub, "VERSION")); !os.IsNotExist(err) {
+			t.Fatalf("no VERSION should be created in the subdirectory")
+		}
+		if !strings.Contains(stderr, "Using "+path) {
+			t.Fatalf("stderr does not report the resolved file: %q", stderr)
+		}
+	})
+}
+
+func TestBump_NPMStore(t *testing.T) {
+	cmdtest.WithTempWD(t, func(tmp string) {
+		pkg := "{\n  \"name\": \"web\",\n  \"version\": \"1.2.3\"\n}\n"
+		if err := os.WriteFile("package.json", []byte(pkg), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		out, err := runBumpWith(t, "--store", "npm")
+		if err != nil {
+			t.Fatalf("execute: %v", err)
+		}
+		want := "{\n  \"name\": \"web\",\n  \"version\": \"1.3.0\"\n}\n"
+		if b, _ := os.ReadFile("package.json"); string(b) != want {
+			t.Fatalf("package.json:\n%s", b)
+		}
+		if !strings.Contains(out, "Current Version: 1.2.3") || !strings.Contains(out, "New Version: 1.3.0") {
+			t.Fatalf("unexpected stdout:\n%s", out)
+		}
+		if _, err := os.Stat("VERSION"); !os.IsNotExist(err) {
+			t.Fatalf("no VERSION file should be created")
+		}
+
+		if _, err := runBumpWith(t, "--store", "nope"); err == nil || !strings.Contains(err.Error(), "unknown store") {
+			t.Fatalf("expected an unknown store error, got %v", err)
+		}
+	})
+}
+
+func TestBump_CargoStore(t *testing.T) {
+	cmdtest.WithTempWD(t, func(tmp string) {
+		manifest := "[package]\nname = \"svc\" # the service\nversion = \"0.4.1\"\n\n[dependencies]\nserde = { version = \"1.0\" }\n"
+		lock := "[[package]]\nname = \"serde\"\nversion = \"0.4.1\"\nsource = \"registry+https://github.com/rust-lang/crates.io-index\"\n\n[[package]]\nname = \"svc\"\nversion = \"0.4.1\"\n"
+		if err := os.WriteFile("Cargo.toml", []byte(manifest), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile("Cargo.lock", []byte(lock), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		out, err := runBumpWith(t, "--store", "cargo")
+		if err != nil {
+			t.Fatalf("execute: %v", err)
+		}
+		if b, _ := os.ReadFile("Cargo.toml"); string(b) != strings.Replace(manifest, "0.4.1", "0.5.0", 1) {
+			t.Fatalf("Cargo.toml:\n%s", b)
+		}
+		if b, _ := os.ReadFile("Cargo.lock"); !strings.HasSuffix(string(b), "name = \"svc\"\nversion = \"0.5.0\"\n") || !strings.Contains(string(b), "name = \"serde\"\nversion = \"0.4.1\"") {
+			t.Fatalf("Cargo.lock:\n%s", b)
+		}
+		if !strings.Contains(out, "Current Version: 0.4.1") || !strings.Contains(out, "New Version: 0.5.0") {
+			t.Fatalf("unexpected stdout:\n%s", out)
+		}
+	})
+}
+
+func TestBump_WriteFailureClaimsNoVersion(t *testing.T) {
+	cmdtest.WithTempWD(t, func(tmp string) {
+		if err := os.WriteFile("package.json", []byte(`{"version":"1.0.0"}`), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile("package-lock.json", []byte(`{"version": 1}`), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		var (
+			out string
+			err error
+		)
+		stderr := cmdtest.CaptureStderr(t, func() {
+			out, err = runBumpWith(t, "--store", "npm")
+		})
+		if err == nil {
+			t.Fatalf("expected the malformed lockfile to fail the write")
+		}
+		if strings.Contains(out, "New Version") {
+			t.Fatalf("a failed write must not report a new version:\n%s", out)
+		}
+		if strings.Contains(out+stderr, "Usage:") {
+			t.Fatalf("a runtime error must not print usage:\n%s%s", out, stderr)
+		}
+	})
+}
+
+func TestBump_ManifestWithoutVersionField(t *testing.T) {
+	cmdtest.WithTempWD(t, func(tmp string) {
+		if err := os.WriteFile("package.json", []byte(`{"name":"web"}`), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		out, err := runBumpWith(t, "--store", "npm")
+		if !errors.Is(err, store.ErrNoVersionField) {
+			t.Fatalf("expected ErrNoVersionField, got %v", err)
+		}
+		if strings.Contains(out, "semver init") {
+			t.Fatalf("init cannot add the field and should not be suggested:\n%s", out)
+		}
+
+		if err := os.Remove("package.json"); err != nil {
+			t.Fatal(err)
+		}
+		if out, _ = runBumpWith(t, "--store", "npm"); !strings.Contains(out, "No package.json found") || strings.Contains(out, "semver init") {
+			t.Fatalf("unexpected message for a missing manifest:\n%s", out)
+		}
+	})
+}
+
+// memStore is an in-memory store.VersionStore.
+type memStore struct{ v string }
+
+func (m *memStore) Read() (string, error) {
+	if m.v == "" {
+		return "", store.ErrNotFound
+	}
+	return m.v, nil
+}
+func (m *memStore) Write(v string) error { m.v = v; return nil }
+func (m *memStore) Describe() string     { return "memory" }
+
+func TestBump_UsesSelectedStore(t *testing.T) {
+	t.Cleanup(func() { cli.SetStore(nil) })
+
+	cmdtest.WithTempWD(t, func(tmp string) {
+		cmdtest.WriteVERSION(t, "9.9.9")
+		mem := &memStore{v: "1.2.3"}
+		cli.SetStore(mem)
+		if _, err := runBumpWith(t); err != nil {
+			t.Fatalf("execute: %v", err)
+		}
+		if mem.v != "1.3.0" {
+			t.Fatalf("expected store to hold 1.3.0, got %q", mem.v)
+		}
+		if got := cmdtest.ReadVERSION(t); got != "9.9.9" {
+			t.Fatalf("VERSION file should be untouched, got %q", got)
+		}
+
+		mem.v = ""
+		out, err := runBumpWith(t)
+		if err != nil {
+			t.Fatalf("execute: %v", err)
+		}
+		if !strings.Contains(out, "No memory found.") {
+			t.Fatalf("unexpected stdout:\n%s", out)
+		}
+
+		// a flag naming a store replaces the one that was set
+		if _, err := runBumpWith(t, "--file", "VERSION"); err != nil {
+			t.Fatalf("execute: %v", err)
+		}
+		if got := cmdtest.ReadVERSION(t); got != "9.10.0" {
+			t.Fatalf("expected --file to bump VERSION to 9.10.0, got %q", got)
+		}
+	})
+}
@@ -0,0 +1,43 @@
+package bump
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/dp1140a/semver/pkg/cli"
+	"github.com/dp1140a/semver/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+// bumpFourPart bumps a major.minor.patch.revision VERSION file, keeping it
+// in four-segment form.
+func bumpFourPart(cmd *cobra.Command, f types.FourPart, kind bumpKind, dry bool) error {
+	if pre, _ := cmd.Flags().GetString("pre"); pre != "" {
+		return errors.New("four-segment versions have no prerelease; --pre cannot be used")
+	}
+
+	var err error
+	switch kind {
+	case bumpPatch:
+		fmt.Println("Bumping Patch")
+		f, err = f.NextPatch()
+	case bumpMinor:
+		fmt.Println("Bumping Minor")
+		f, err = f.NextMinor()
+	case bumpMajor:
+		fmt.Println("Bumping Major")
+		f, err = f.NextMajor()
+	case bumpRevision:
+		fmt.Println("Bumping Revision")
+		f, err = f.NextRevision()
+	case bumpPre:
+		return errors.New("four-segment versions have no prerelease to bump")
+	default:
+		return fmt.Errorf("unknown bump kind: %v", kind)
+	}
+	if err != nil {
+		return err
+	}
+
+	return cli.ApplyVersion(f.String(), dry)
+}
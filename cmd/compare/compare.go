@@ -0,0 +1,103 @@
+package compare
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/dp1140a/semver/cmd"
+	"github.com/dp1140a/semver/pkg/cli"
+	"github.com/dp1140a/semver/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+// Exit codes for compare. 0 means the versions have equal precedence.
+const (
+	exitGreater = 1
+	exitLess    = 2
+	exitInvalid = 3
+)
+
+var CompareCmd = &cobra.Command{
+	Use:   "compare <A> <B>",
+	Short: "Compare two versions by precedence",
+	Long: `Print -1, 0 or 1 when A has lower, equal or higher precedence than B. Build metadata is ignored.
+The exit status matches the result: 0 when equal, 1 when A > B and 2 when A < B.
+Invalid input, a wrong number of arguments or an unknown flag exits 3.
+When no arguments are given the two versions are read from stdin, one per line.`,
+	Args:              pairArgs,
+	PersistentPreRunE: selectStore,
+	SilenceErrors:     true,
+	SilenceUsage:      true,
+	RunE: func(c *cobra.Command, args []string) error {
+		a, b, err := readPair(c, args)
+		if err != nil {
+			return invalid(err)
+		}
+
+		res := a.Compare(b)
+		fmt.Println(res)
+		switch res {
+		case 1:
+			return &cmd.ExitError{Code: exitGreater}
+		case -1:
+			return &cmd.ExitError{Code: exitLess}
+		}
+		return nil
+	},
+}
+
+func init() {
+	cmd.RootCmd.AddCommand(CompareCmd)
+	cli.AddLooseFlag(CompareCmd)
+	CompareCmd.SetFlagErrorFunc(flagError)
+}
+
+// pairArgs accepts up to two versions. Like flagError it reports a bad call
+// as invalid input, since cobra's exit 1 would read as "A > B".
+func pairArgs(c *cobra.Command, args []string) error {
+	if err := cobra.MaximumNArgs(2)(c, args); err != nil {
+		return invalid(err)
+	}
+	return nil
+}
+
+// selectStore is cmd.SelectStore with a failure, such as an unknown
+// --store, reported as invalid input.
+func selectStore(c *cobra.Command, args []string) error {
+	if err := cmd.SelectStore(c); err != nil {
+		return invalid(err)
+	}
+	return nil
+}
+
+func flagError(c *cobra.Command, err error) error {
+	return invalid(err)
+}
+
+// invalid reports err on stderr, since SilenceErrors is set, and exits 3.
+func invalid(err error) error {
+	fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+	return &cmd.ExitError{Code: exitInvalid}
+}
+
+// readPair parses exactly two versions from args, or from stdin when no
+// args were given.
+func readPair(c *cobra.Command, args []string) (types.Version, types.Version, error) {
+	loose, _ := c.Flags().GetBool("loose")
+	vals, err := cli.ArgsOrLines(args, c.InOrStdin())
+	if err != nil {
+		return types.Version{}, types.Version{}, err
+	}
+	if len(vals) != 2 {
+		return types.Version{}, types.Version{}, fmt.Errorf("expected 2 versions, got %d", len(vals))
+	}
+	a, err := cli.ParseVersion(vals[0], loose)
+	if err != nil {
+		return types.Version{}, types.Version{}, err
+	}
+	b, err := cli.ParseVersion(vals[1], loose)
+	if err != nil {
+		return types.Version{}, types.Version{}, err
+	}
+	return a, b, nil
+}
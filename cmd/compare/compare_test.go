@@ -0,0 +1,127 @@
+package compare
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dp1140a/semver/cmd/internal/cmdtest"
+)
+
+// run executes the root command with args and stdin, returning the trimmed
+// output and exit status.
+func run(t *testing.T, stdin string, args ...string) (string, int) {
+	t.Helper()
+	out, err := cmdtest.Run(t, stdin, args...)
+	return strings.TrimSpace(out), cmdtest.ExitCode(err)
+}
+
+func TestCompare_Args(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		want     string
+		wantCode int
+	}{
+		{"1.2.3", "1.2.3+build.7", "0", 0},
+		{"2.0.0", "1.9.9", "1", 1},
+		{"1.0.0-rc.1", "1.0.0", "-1", 2},
+		{"v1.10.0", "1.9.0", "1", 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.a+"_"+tt.b, func(t *testing.T) {
+			out, code := run(t, "", "compare", tt.a, tt.b)
+			if out != tt.want || code != tt.wantCode {
+				t.Fatalf("compare %s %s = %q (exit %d), want %q (exit %d)", tt.a, tt.b, out, code, tt.want, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestCompare_Stdin(t *testing.T) {
+	out, code := run(t, "1.2.3\n\n1.3.0\n", "compare")
+	if out != "-1" || code != 2 {
+		t.Fatalf("got %q (exit %d), want -1 (exit 2)", out, code)
+	}
+}
+
+func TestCompare_Invalid(t *testing.T) {
+	if _, code := run(t, "", "compare", "1.2", "1.2.3"); code != 3 {
+		t.Fatalf("invalid version: exit %d, want 3", code)
+	}
+	if _, code := run(t, "1.2.3\n", "compare"); code != 3 {
+		t.Fatalf("single stdin version: exit %d, want 3", code)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		a, b, want string
+	}{
+		{"1.2.3", "2.0.0", "major"},
+		{"1.2.3", "2.0.0-rc.1", "premajor"},
+		{"1.2.3", "1.3.0", "minor"},
+		{"1.2.3", "1.2.4", "patch"},
+		{"1.2.3-rc.1", "1.2.3-rc.2", "prerelease"},
+		{"1.2.3+a", "1.2.3+b", "build"},
+		{"1.2.3", "1.2.3", "none"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.a+"_"+tt.b, func(t *testing.T) {
+			out, code := run(t, "", "diff", tt.a, tt.b)
+			if out != tt.want || code != 0 {
+				t.Fatalf("diff %s %s = %q (exit %d), want %q", tt.a, tt.b, out, code, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiff_StdinAndInvalid(t *testing.T) {
+	if out, _ := run(t, "1.2.3\n2.0.0\n", "diff"); out != "major" {
+		t.Fatalf("diff from stdin = %q, want major", out)
+	}
+	var code int
+	stderr := cmdtest.CaptureStderr(t, func() {
+		_, code = run(t, "", "diff", "1.2.3", "nope")
+	})
+	if code != 3 || strings.Contains(stderr, "Usage:") {
+		t.Fatalf("invalid version: exit %d, stderr %q; want exit 3 without usage", code, stderr)
+	}
+}
+
+func TestCompare_Loose(t *testing.T) {
+	out, code := run(t, "", "compare", "--loose", "release-1.10", "1.9.0.1")
+	if out != "1" || code != 1 {
+		t.Fatalf("got %q (exit %d), want 1 (exit 1)", out, code)
+	}
+	out, code = run(t, "", "diff", "--loose", "v1.2", "1.3")
+	if out != "minor" || code != 0 {
+		t.Fatalf("diff: got %q (exit %d), want minor", out, code)
+	}
+	if _, code := run(t, "", "compare", "--loose=false", "1.2", "1.2.3"); code != 3 {
+		t.Fatalf("strict compare: exit %d, want 3", code)
+	}
+}
+
+func TestCompare_UsageErrors(t *testing.T) {
+	for _, args := range [][]string{
+		{"compare", "1.0.0", "2.0.0", "3.0.0"},
+		{"compare", "--bogus", "1.0.0", "2.0.0"},
+		{"diff", "1.0.0", "2.0.0", "3.0.0"},
+		{"diff", "--bogus", "1.0.0", "2.0.0"},
+		{"compare", "--store", "bogus", "1.0.0", "2.0.0"},
+		{"diff", "--store", "bogus", "1.0.0", "2.0.0"},
+	} {
+		var (
+			out  string
+			code int
+		)
+		stderr := cmdtest.CaptureStderr(t, func() {
+			out, code = run(t, "", args...)
+		})
+		if code != 3 || out != "" {
+			t.Fatalf("%q: got %q (exit %d), want exit 3", args, out, code)
+		}
+		if !strings.Contains(stderr, "Error: ") || strings.Contains(stderr, "Usage:") {
+			t.Fatalf("%q: expected a one-line error on stderr, got %q", args, stderr)
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package compare
+
+import (
+	"fmt"
+
+	"github.com/dp1140a/semver/cmd"
+	"github.com/dp1140a/semver/pkg/cli"
+	"github.com/dp1140a/semver/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+var DiffCmd = &cobra.Command{
+	Use:   "diff <A> <B>",
+	Short: "Report the most significant component that differs between two versions",
+	Long: `Print one of: major, premajor, minor, preminor, patch, prepatch, prerelease, build or none.
+The "pre" variants are reported when the higher of the two versions is a prerelease.
+When no arguments are given the two versions are read from stdin, one per line. Invalid input exits 3.`,
+	Args:              pairArgs,
+	PersistentPreRunE: selectStore,
+	SilenceErrors:     true,
+	SilenceUsage:      true,
+	RunE: func(c *cobra.Command, args []string) error {
+		a, b, err := readPair(c, args)
+		if err != nil {
+			return invalid(err)
+		}
+		fmt.Println(types.Diff(a, b))
+		return nil
+	},
+}
+
+func init() {
+	cmd.RootCmd.AddCommand(DiffCmd)
+	cli.AddLooseFlag(DiffCmd)
+	DiffCmd.SetFlagErrorFunc(flagError)
+}
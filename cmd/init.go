@@ -5,11 +5,14 @@ package cmd
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 
+	"github.com/dp1140a/semver/pkg/cli"
+	"github.com/dp1140a/semver/pkg/store"
 	"github.com/dp1140a/semver/pkg/util"
 	"github.com/spf13/cobra"
 )
@@ -46,11 +49,20 @@ func runInit() {
 	reader := bufio.NewReader(os.Stdin)
 
 	/**
-	Check if VERSION file exists
+	Check if the store already exists
 	*/
-	if util.VersionFileExists(cwd) { // If file exists ask to overwrite
-
-		fmt.Printf("VERSION fle was found do you want to overwrite it [Y/n]?")
+	current := cli.Store()
+	cur, err := current.Read()
+	if err != nil && !errors.Is(err, store.ErrNotFound) {
+		fmt.Printf("Error reading version from %s: %v. Exiting", current.Describe(), err)
+		os.Exit(-1)
+	}
+	if err == nil { // If the store exists, even empty, ask to overwrite
+		if cur == "" {
+			fmt.Printf("%s exists but holds no version do you want to overwrite it [Y/n]?", current.Describe())
+		} else {
+			fmt.Printf("A version was found in %s do you want to overwrite it [Y/n]?", current.Describe())
+		}
 		overwrite, _ := reader.ReadString('\n')
 		overwrite = strings.TrimSuffix(overwrite, "\n")
 		if overwrite == "" {
@@ -58,9 +70,9 @@ func runInit() {
 		}
 		fmt.Println(overwrite)
 		if strings.ToLower(overwrite) == "y" {
-			fmt.Println("Overwriting VERSION file.  Continuing . . . ")
+			fmt.Printf("Overwriting %s.  Continuing . . . \n", current.Describe())
 		} else if strings.ToLower(overwrite) == "n" {
-			fmt.Println("Please delete the VERSION file and restart")
+			fmt.Printf("Please delete %s and restart\n", current.Describe())
 			os.Exit(0)
 		} else {
 			fmt.Println("I don't understand your response. Exiting.")
@@ -97,10 +109,9 @@ func runInit() {
 		}
 	}
 	fmt.Println(startingVersion)
-	fmt.Printf("Creating VERSION file with starting version %v\n", startingVersion)
-	err := util.WriteVersionFile(startingVersion)
-	if err != nil {
-		fmt.Printf("Error writing VERSION file: %v. Exiting", err)
+	fmt.Printf("Writing starting version %v to %s\n", startingVersion, current.Describe())
+	if err := current.Write(startingVersion); err != nil {
+		fmt.Printf("Error writing version to %s: %v. Exiting", current.Describe(), err)
 		os.Exit(-1)
 	}
 }
@@ -0,0 +1,134 @@
+// Package cmdtest holds the helpers the command tests share: running the
+// root command with clean flags, capturing its output and working in a
+// throwaway directory.
+package cmdtest
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/dp1140a/semver/cmd"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// Execute runs the root command with args after resetting every flag to its
+// default, since cobra keeps flag values between Execute calls.
+func Execute(args ...string) error {
+	ResetFlags()
+	cmd.RootCmd.SetArgs(args)
+	return cmd.RootCmd.Execute()
+}
+
+// Run is Execute with stdin as standard input, returning what was printed on
+// stdout.
+func Run(t *testing.T, stdin string, args ...string) (string, error) {
+	t.Helper()
+	cmd.RootCmd.SetIn(strings.NewReader(stdin))
+	t.Cleanup(func() { cmd.RootCmd.SetIn(nil) })
+	var err error
+	out := CaptureStdout(t, func() {
+		err = Execute(args...)
+	})
+	return out, err
+}
+
+// ResetFlags sets every flag of every command back to its default and drops
+// the positional args of the last run, which pflag keeps when a command is
+// later run without any.
+func ResetFlags() {
+	reset := func(f *pflag.Flag) {
+		_ = f.Value.Set(f.DefValue)
+		f.Changed = false
+	}
+	var walk func(c *cobra.Command)
+	walk = func(c *cobra.Command) {
+		c.Flags().VisitAll(reset)
+		c.PersistentFlags().VisitAll(reset)
+		_ = c.Flags().Parse([]string{"--"})
+		for _, sub := range c.Commands() {
+			walk(sub)
+		}
+	}
+	walk(cmd.RootCmd)
+}
+
+// ExitCode maps the error from Execute to the status cmd.Execute would exit
+// with.
+func ExitCode(err error) int {
+	var exitErr *cmd.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.Code
+	}
+	if err != nil {
+		return 1
+	}
+	return 0
+}
+
+// WithTempWD runs f with a new temporary directory as the working directory.
+func WithTempWD(t *testing.T, f func(tmp string)) {
+	t.Helper()
+	orig, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("getwd: %v", err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(orig) })
+	tmp := t.TempDir()
+	if err := os.Chdir(tmp); err != nil {
+		t.Fatalf("chdir temp: %v", err)
+	}
+	f(tmp)
+}
+
+func WriteVERSION(t *testing.T, v string) {
+	t.Helper()
+	if err := os.WriteFile("VERSION", []byte(v+"\n"), 0o644); err != nil {
+		t.Fatalf("write VERSION: %v", err)
+	}
+}
+
+func ReadVERSION(t *testing.T) string {
+	t.Helper()
+	b, err := os.ReadFile("VERSION")
+	if err != nil {
+		t.Fatalf("read VERSION: %v", err)
+	}
+	return strings.TrimSpace(string(b))
+}
+
+// CaptureStdout runs fn while capturing stdout.
+func CaptureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+	return capture(t, &os.Stdout, fn)
+}
+
+// CaptureStderr runs fn while capturing stderr.
+func CaptureStderr(t *testing.T, fn func()) string {
+	t.Helper()
+	return capture(t, &os.Stderr, fn)
+}
+
+func capture(t *testing.T, f **os.File, fn func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("pipe: %v", err)
+	}
+	orig := *f
+	*f = w
+	defer func() { *f = orig }()
+	done := make(chan string)
+	go func() {
+		var buf bytes.Buffer
+		_, _ = io.Copy(&buf, r)
+		done <- buf.String()
+	}()
+	fn()
+	_ = w.Close()
+	return <-done
+}
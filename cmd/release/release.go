@@ -0,0 +1,63 @@
+package release
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/dp1140a/semver/cmd"
+	"github.com/dp1140a/semver/pkg/cli"
+	"github.com/dp1140a/semver/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+var ReleaseCmd = &cobra.Command{
+	Use:     "release",
+	Aliases: []string{"finalize"},
+	Short:   "Finalize a prerelease (e.g., 2.0.0-rc.3 -> 2.0.0)",
+	Long: `Strip the prerelease from the version in the VERSION file without touching major, minor or patch.
+Build metadata is kept unless --strip-build is given. Fails if the version is already a final release.`,
+	Args: cobra.NoArgs,
+	RunE: runRelease,
+}
+
+func init() {
+	cmd.RootCmd.AddCommand(ReleaseCmd)
+	ReleaseCmd.Flags().BoolP(
+		"dry", "d", false,
+		"Show what the released version would be; do not write VERSION",
+	)
+	ReleaseCmd.Flags().Bool("strip-build", false, "Also remove the build metadata")
+}
+
+func runRelease(cmd *cobra.Command, args []string) error {
+	dry, _ := cmd.Flags().GetBool("dry")
+	stripBuild, _ := cmd.Flags().GetBool("strip-build")
+
+	cwd, _ := os.Getwd()
+	cur, err := cli.ReadVersion()
+	if err != nil {
+		return err
+	}
+	if cur == "" {
+		cli.PrintNoVersionMsg(cwd)
+		return nil
+	}
+
+	fmt.Printf("Current Version: %s\n", cur)
+
+	v, err := types.Parse(cur)
+	if err != nil {
+		return err
+	}
+	if !v.IsPrerelease() {
+		return fmt.Errorf("version %s is already a final release; nothing to release", v.String())
+	}
+
+	fmt.Println("Releasing")
+	v.PreRelease = ""
+	if stripBuild {
+		v.Build = ""
+	}
+
+	return cli.ApplyVersion(v.String(), dry)
+}
@@ -0,0 +1,72 @@
+package release
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dp1140a/semver/cmd/internal/cmdtest"
+)
+
+func TestRelease_KeepsBuildByDefault(t *testing.T) {
+	cmdtest.WithTempWD(t, func(tmp string) {
+		cmdtest.WriteVERSION(t, "2.0.0-rc.3+build.9")
+		out := cmdtest.CaptureStdout(t, func() {
+			if err := cmdtest.Execute("release"); err != nil {
+				t.Fatalf("execute: %v", err)
+			}
+		})
+		if got := cmdtest.ReadVERSION(t); got != "2.0.0+build.9" {
+			t.Fatalf("expected VERSION=2.0.0+build.9, got %q", got)
+		}
+		if !strings.Contains(out, "Releasing") || !strings.Contains(out, "New Version: 2.0.0+build.9") {
+			t.Fatalf("unexpected stdout:\n%s", out)
+		}
+	})
+}
+
+func TestRelease_StripBuild(t *testing.T) {
+	cmdtest.WithTempWD(t, func(tmp string) {
+		cmdtest.WriteVERSION(t, "2.0.0-rc.3+build.9")
+		cmdtest.CaptureStdout(t, func() {
+			if err := cmdtest.Execute("finalize", "--strip-build"); err != nil {
+				t.Fatalf("execute: %v", err)
+			}
+		})
+		if got := cmdtest.ReadVERSION(t); got != "2.0.0" {
+			t.Fatalf("expected VERSION=2.0.0, got %q", got)
+		}
+	})
+}
+
+func TestRelease_DryRun(t *testing.T) {
+	cmdtest.WithTempWD(t, func(tmp string) {
+		cmdtest.WriteVERSION(t, "1.4.0-beta.1")
+		out := cmdtest.CaptureStdout(t, func() {
+			if err := cmdtest.Execute("release", "--dry"); err != nil {
+				t.Fatalf("execute: %v", err)
+			}
+		})
+		if got := cmdtest.ReadVERSION(t); got != "1.4.0-beta.1" {
+			t.Fatalf("expected VERSION unchanged, got %q", got)
+		}
+		if !strings.Contains(out, "[dry-run] New Version would be: 1.4.0") {
+			t.Fatalf("unexpected stdout:\n%s", out)
+		}
+	})
+}
+
+func TestRelease_RefusesFinalVersion(t *testing.T) {
+	cmdtest.WithTempWD(t, func(tmp string) {
+		cmdtest.WriteVERSION(t, "1.4.0+build.2")
+		var err error
+		cmdtest.CaptureStdout(t, func() {
+			err = cmdtest.Execute("release", "--strip-build")
+		})
+		if err == nil || !strings.Contains(err.Error(), "already a final release") {
+			t.Fatalf("expected already-final error, got %v", err)
+		}
+		if got := cmdtest.ReadVERSION(t); got != "1.4.0+build.2" {
+			t.Fatalf("expected VERSION unchanged, got %q", got)
+		}
+	})
+}
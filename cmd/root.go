@@ -4,12 +4,13 @@ Copyright © 2023 NAME HERE <EMAIL ADDRESS>
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"strings"
 
+	"github.com/dp1140a/semver/pkg/cli"
 	"github.com/dp1140a/semver/pkg/types"
-	"github.com/dp1140a/semver/pkg/util"
 	"github.com/spf13/cobra"
 )
 
@@ -20,38 +21,84 @@ var RootCmd = &cobra.Command{
 	Long: `Run by itself semver will return the current version string. For example if the current version is 1.2.3:
    $ semver --> 1.2.3
 `,
-	// Uncomment the following line if your bare application
-	// has an action associated with it:
-	Run: func(cmd *cobra.Command, args []string) {
-		runVersion("string")
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return SelectStore(cmd)
 	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runVersion("string")
+	},
+}
+
+// SelectStore picks the version store for this run from --store, --file,
+// --dir and $SEMVER_FILE; see cli.SelectStore. A --store left at its default
+// counts as not given, so a store set with cli.SetStore is kept. It is the
+// root's persistent pre-run; commands with their own exit codes call it from
+// theirs to report a failure their way.
+func SelectStore(cmd *cobra.Command) error {
+	// args and flags have been accepted by now, so later errors are not
+	// usage errors
+	cmd.SilenceUsage = true
+	var name string
+	if cmd.Flags().Changed("store") {
+		name, _ = cmd.Flags().GetString("store")
+	}
+	file, _ := cmd.Flags().GetString("file")
+	dir, _ := cmd.Flags().GetString("dir")
+	return cli.SelectStore(name, file, dir)
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
 func Execute() {
 	err := RootCmd.Execute()
+	var exitErr *ExitError
+	if errors.As(err, &exitErr) {
+		os.Exit(exitErr.Code)
+	}
 	if err != nil {
 		os.Exit(1)
 	}
 
 }
 
-func runVersion(format string) {
+// ExitError asks Execute to end the process with Code. Predicate commands
+// like satisfies answer through the exit status, so they return one instead
+// of a regular error and set SilenceErrors and SilenceUsage.
+type ExitError struct {
+	Code int
+}
+
+func (e *ExitError) Error() string {
+	return fmt.Sprintf("exit status %d", e.Code)
+}
+
+func runVersion(format string) error {
 	cwd, _ := os.Getwd()
-	if !util.VersionFileExists(cwd) {
-		fmt.Printf("No VERSION file found in %v.\nPlease either change directory or first run 'semver init'\n", cwd)
-		os.Exit(0)
+	cur, err := cli.ReadVersion()
+	if err != nil {
+		return fmt.Errorf("error reading version from %s: %w", cli.Store().Describe(), err)
+	}
+	if cur == "" {
+		cli.PrintNoVersionMsg(cwd)
+		return nil
 	}
 
-	CUR_VER, err := os.ReadFile("VERSION")
+	if f, err := types.ParseFourPart(cur); err == nil {
+		return printVersion(format, f)
+	}
+	v, err := types.Parse(cur)
 	if err != nil {
-		fmt.Printf("Error reading VERSION file. %v", err)
+		return err
 	}
+	return printVersion(format, &v)
+}
 
-	versionStr := strings.TrimSpace(string(CUR_VER)) // <-- key fix
-	v := types.NewVersionFromString(versionStr)
-
+// printVersion prints v in one of the formats runVersion accepts.
+func printVersion(format string, v interface {
+	String() string
+	Json() string
+	PrettyPrint() string
+}) error {
 	switch strings.ToLower(format) {
 	case "string":
 		fmt.Println(v.String())
@@ -62,6 +109,11 @@ func runVersion(format string) {
 	default:
 		fmt.Printf("%v is an unknown format. Optons are [string | json | pretty]\n\n", format)
 	}
+	return nil
 }
 
-func init() {}
+func init() {
+	RootCmd.PersistentFlags().String("file", "", "Version file or manifest to use (default: searched for up to the git root; env SEMVER_FILE)")
+	RootCmd.PersistentFlags().String("dir", "", "Directory containing the version file or manifest")
+	RootCmd.PersistentFlags().String("store", "file", "Where the version is kept: "+strings.Join(cli.StoreKinds(), ", "))
+}
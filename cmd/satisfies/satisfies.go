@@ -0,0 +1,102 @@
+package satisfies
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/dp1140a/semver/cmd"
+	"github.com/dp1140a/semver/pkg/cli"
+	"github.com/dp1140a/semver/pkg/constraint"
+	"github.com/spf13/cobra"
+)
+
+// Exit codes besides 0 (satisfied), following test(1) and grep(1).
+const (
+	exitNotSatisfied = 1
+	exitInvalid      = 2
+)
+
+var SatisfiesCmd = &cobra.Command{
+	Use:   "satisfies <range> [version]",
+	Short: "Check whether a version satisfies a range",
+	Long: `Exit 0 if the version satisfies the range and 1 if it does not, so it can gate Makefiles and CI steps:
+   $ semver satisfies '>=2.0.0 <3.0.0' && echo "2.x release"
+The version defaults to the one in the VERSION file. Ranges use npm syntax (^1.4, ~1.4.2, 1.x, 1.2.3 - 2.0.0, ||).
+Invalid input, a wrong number of arguments or an unknown flag exits 2.`,
+	Args: func(c *cobra.Command, args []string) error {
+		if err := cobra.RangeArgs(1, 2)(c, args); err != nil {
+			return invalid(err)
+		}
+		return nil
+	},
+	// an unknown --store must not look like "not satisfied" either
+	PersistentPreRunE: func(c *cobra.Command, args []string) error {
+		if err := cmd.SelectStore(c); err != nil {
+			return invalid(err)
+		}
+		return nil
+	},
+	SilenceErrors: true,
+	SilenceUsage:  true,
+	RunE:          runSatisfies,
+}
+
+func init() {
+	cmd.RootCmd.AddCommand(SatisfiesCmd)
+	cli.AddLooseFlag(SatisfiesCmd)
+	SatisfiesCmd.Flags().Bool("explain", false, "Print which comparator the version failed (or which range it satisfied)")
+	// a typo must not look like "not satisfied", which also exits 1
+	SatisfiesCmd.SetFlagErrorFunc(func(c *cobra.Command, err error) error {
+		return invalid(err)
+	})
+}
+
+func runSatisfies(c *cobra.Command, args []string) error {
+	explain, _ := c.Flags().GetBool("explain")
+	loose, _ := c.Flags().GetBool("loose")
+
+	rng, err := constraint.Parse(args[0])
+	if err != nil {
+		return invalid(err)
+	}
+
+	var verStr string
+	if len(args) == 2 {
+		verStr = args[1]
+	} else {
+		cwd, _ := os.Getwd()
+		verStr, err = cli.ReadVersion()
+		if err != nil {
+			return invalid(err)
+		}
+		if verStr == "" {
+			cli.PrintNoVersionMsg(cwd)
+			return &cmd.ExitError{Code: exitInvalid}
+		}
+	}
+	v, err := cli.ParseVersion(verStr, loose)
+	if err != nil {
+		return invalid(err)
+	}
+
+	ok, reasons := rng.Validate(v)
+	if explain {
+		if ok {
+			fmt.Printf("%s satisfies %q\n", v.String(), rng.String())
+		} else {
+			for _, r := range reasons {
+				fmt.Println(r)
+			}
+		}
+	}
+	if !ok {
+		return &cmd.ExitError{Code: exitNotSatisfied}
+	}
+	return nil
+}
+
+// invalid reports err on stderr, since SilenceErrors is set, and exits 2.
+func invalid(err error) error {
+	fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+	return &cmd.ExitError{Code: exitInvalid}
+}
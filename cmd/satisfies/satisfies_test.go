@@ -0,0 +1,111 @@
+package satisfies
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dp1140a/semver/cmd/internal/cmdtest"
+)
+
+func run(t *testing.T, args ...string) (string, int) {
+	t.Helper()
+	var err error
+	out := cmdtest.CaptureStdout(t, func() {
+		err = cmdtest.Execute(append([]string{"satisfies"}, args...)...)
+	})
+	return out, cmdtest.ExitCode(err)
+}
+
+func TestSatisfies_ExplicitVersion(t *testing.T) {
+	tests := []struct {
+		rng, version string
+		want         int
+	}{
+		{"2.x", "2.4.1", 0},
+		{"2.x", "1.9.0", 1},
+		{">=1.2.0 <2.0.0", "1.5.0", 0},
+		{"^1.4 || ^2", "3.0.0", 1},
+		{"2.x", "2.0.0-rc.1", 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.rng+"_"+tt.version, func(t *testing.T) {
+			out, code := run(t, tt.rng, tt.version)
+			if code != tt.want {
+				t.Fatalf("exit code = %d, want %d", code, tt.want)
+			}
+			if out != "" {
+				t.Fatalf("expected no output without --explain, got:\n%s", out)
+			}
+		})
+	}
+}
+
+func TestSatisfies_ReadsVersionFile(t *testing.T) {
+	cmdtest.WithTempWD(t, func(tmp string) {
+		cmdtest.WriteVERSION(t, "2.3.0")
+		if _, code := run(t, "2.x"); code != 0 {
+			t.Fatalf("expected 2.3.0 to satisfy 2.x, exit %d", code)
+		}
+		if _, code := run(t, "^3"); code != 1 {
+			t.Fatalf("expected 2.3.0 to fail ^3, exit %d", code)
+		}
+	})
+}
+
+func TestSatisfies_Explain(t *testing.T) {
+	out, code := run(t, "--explain", ">=2.0.0 <3.0.0", "1.4.0")
+	if code != 1 {
+		t.Fatalf("exit code = %d, want 1", code)
+	}
+	if !strings.Contains(out, "1.4.0 does not satisfy >=2.0.0") {
+		t.Fatalf("explanation missing failed comparator:\n%s", out)
+	}
+
+	out, code = run(t, "--explain", "^1.2", "1.4.0")
+	if code != 0 || !strings.Contains(out, `1.4.0 satisfies "^1.2"`) {
+		t.Fatalf("unexpected result %d:\n%s", code, out)
+	}
+
+	out, _ = run(t, "--explain", "1.x", "1.4.0-rc.1")
+	if !strings.Contains(out, "is a prerelease") {
+		t.Fatalf("explanation missing prerelease note:\n%s", out)
+	}
+}
+
+func TestSatisfies_InvalidInput(t *testing.T) {
+	if _, code := run(t, ">=nope", "1.0.0"); code != 2 {
+		t.Fatalf("invalid range: exit code = %d, want 2", code)
+	}
+	if _, code := run(t, "1.x", "1.0"); code != 2 {
+		t.Fatalf("invalid version: exit code = %d, want 2", code)
+	}
+}
+
+func TestSatisfies_Loose(t *testing.T) {
+	if _, code := run(t, "--loose=false", "1.x", "1.2"); code != 2 {
+		t.Fatalf("strict: exit %d, want 2", code)
+	}
+	if _, code := run(t, "--loose", "1.x", "1.2"); code != 0 {
+		t.Fatalf("loose: exit %d, want 0", code)
+	}
+}
+
+func TestSatisfies_UsageErrors(t *testing.T) {
+	for _, args := range [][]string{
+		{},
+		{"2.x", "1", "2", "3"},
+		{"--bogus", "1.0.0", "2.x"},
+		{"--store", "bogus", "^1", "1.0.0"},
+	} {
+		var code int
+		stderr := cmdtest.CaptureStderr(t, func() {
+			_, code = run(t, args...)
+		})
+		if code != 2 {
+			t.Fatalf("%q: exit code = %d, want 2", args, code)
+		}
+		if !strings.Contains(stderr, "Error: ") {
+			t.Fatalf("%q: expected an error on stderr, got %q", args, stderr)
+		}
+	}
+}
@@ -50,29 +50,26 @@ var buildCmd = &cobra.Command{
 		fmt.Printf("Current Version: %s\n", cur)
 		fmt.Println("Setting Build Metadata")
 
-		v := types.NewVersionFromString(strings.TrimSpace(cur))
+		v, err := types.Parse(cur)
+		if err != nil {
+			return err
+		}
 
 		switch {
 		case clear:
-			v.SetBuild("")
+			val = ""
 		case useGit:
 			out, err := exec.Command("git", "rev-parse", "--short", "HEAD").CombinedOutput()
 			if err != nil {
 				return fmt.Errorf("error getting git build info: %w", err)
 			}
-			v.SetBuild(strings.TrimSpace(string(out)))
-		default:
-			v.SetBuild(val)
+			val = strings.TrimSpace(string(out))
 		}
-
-		next := v.String()
-		if dry {
-			cli.RenderDry(next)
-			return nil
+		if v, err = v.WithBuild(val); err != nil {
+			return err
 		}
 
-		fmt.Printf("New Version: %s\n", next)
-		return cli.WriteVersion(next)
+		return cli.ApplyVersion(v.String(), dry)
 	},
 }
 
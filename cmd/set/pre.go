@@ -35,20 +35,18 @@ var preCmd = &cobra.Command{
 		fmt.Printf("Current Version: %s\n", cur)
 		fmt.Println("Setting Prerelease")
 
-		v := types.NewVersionFromString(cur)
+		v, err := types.Parse(cur)
+		if err != nil {
+			return err
+		}
 		if clr {
-			v.SetPre("")
-		} else {
-			v.SetPre(val)
+			val = ""
 		}
-
-		next := v.String()
-		if dry {
-			cli.RenderDry(next)
-			return nil
+		if v, err = v.WithPre(val); err != nil {
+			return err
 		}
-		fmt.Printf("New Version: %s\n", next)
-		return cli.WriteVersion(next)
+
+		return cli.ApplyVersion(v.String(), dry)
 	},
 }
 
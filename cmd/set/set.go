@@ -14,7 +14,7 @@ import (
 var SetCmd = &cobra.Command{
 	Use:   "set <version>",
 	Short: "Set the full semantic version",
-	Long:  "Set the semantic version in the VERSION file (e.g., 1.2.3 or 1.2.3-rc.1+build.5).",
+	Long:  "Set the semantic version in the VERSION file (e.g., 1.2.3 or 1.2.3-rc.1+build.5). A four-segment version such as 1.2.3.4 is also accepted.",
 	Args:  cobra.ExactArgs(1),
 	RunE: func(c *cobra.Command, args []string) error {
 		verArg := strings.TrimSpace(args[0])
@@ -28,10 +28,25 @@ func init() {
 		"dry", "d", false,
 		"Show what the new version would be; do not write VERSION",
 	)
+	cli.AddLooseFlag(SetCmd)
 }
 
 func runSetVersion(cmd *cobra.Command, verArg string) error {
 	dry, _ := cmd.Flags().GetBool("dry")
+	loose, _ := cmd.Flags().GetBool("loose")
+
+	// --loose coerces a four-segment version to SemVer like any other
+	// string; without it one is kept as is
+	var next string
+	if f, err := types.ParseFourPart(verArg); err == nil && !loose {
+		next = f.String()
+	} else {
+		v, err := cli.ParseVersion(verArg, loose)
+		if err != nil {
+			return err
+		}
+		next = v.String()
+	}
 
 	cwd, _ := os.Getwd()
 	cur, err := cli.ReadVersion()
@@ -42,18 +57,16 @@ func runSetVersion(cmd *cobra.Command, verArg string) error {
 		cli.PrintNoVersionMsg(cwd)
 		return nil
 	}
+	// never replace something that is not a version, such as a manifest
+	// read as a plain file
+	if _, err := types.ParseFourPart(cur); err != nil {
+		if _, err := types.Parse(cur); err != nil {
+			return fmt.Errorf("%s does not hold a version: %w", cli.Store().Describe(), err)
+		}
+	}
 
 	fmt.Printf("Current Version: %s\n", cur)
 	fmt.Println("Setting Version")
 
-	v := types.NewVersionFromString(verArg)
-	next := v.String()
-
-	if dry {
-		cli.RenderDry(next)
-		return nil
-	}
-
-	fmt.Printf("New Version: %s\n", next)
-	return cli.WriteVersion(next)
+	return cli.ApplyVersion(next, dry)
 }
@@ -1,69 +1,25 @@
 package set
 
 import (
-	"bytes"
-	"io"
+	"errors"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
 
-	"github.com/dp1140a/semver/cmd"
+	"github.com/dp1140a/semver/cmd/internal/cmdtest"
+	"github.com/dp1140a/semver/pkg/types"
 )
 
-func withTempWD(t *testing.T, f func(tmp string)) {
-	t.Helper()
-	orig, err := os.Getwd()
-	if err != nil {
-		t.Fatalf("getwd: %v", err)
-	}
-	t.Cleanup(func() { _ = os.Chdir(orig) })
-	tmp := t.TempDir()
-	if err := os.Chdir(tmp); err != nil {
-		t.Fatalf("chdir temp: %v", err)
-	}
-	f(tmp)
-}
-
-func writeVERSION(t *testing.T, v string) {
-	t.Helper()
-	if err := os.WriteFile("VERSION", []byte(v+"\n"), 0o644); err != nil {
-		t.Fatalf("write VERSION: %v", err)
-	}
-}
-
-func readVERSION(t *testing.T) string {
-	t.Helper()
-	b, err := os.ReadFile("VERSION")
-	if err != nil {
-		t.Fatalf("read VERSION: %v", err)
-	}
-	return strings.TrimSpace(string(b))
-}
-
-func captureStdout(t *testing.T, fn func()) string {
-	t.Helper()
-	orig := os.Stdout
-	r, w, _ := os.Pipe()
-	os.Stdout = w
-	defer func() { os.Stdout = orig }()
-	fn()
-	_ = w.Close()
-	var buf bytes.Buffer
-	_, _ = io.Copy(&buf, r)
-	return buf.String()
-}
-
 func TestSetVersion_WritesAndPrints(t *testing.T) {
-	withTempWD(t, func(tmp string) {
-		writeVERSION(t, "1.2.3")
-		out := captureStdout(t, func() {
-			cmd.RootCmd.SetArgs([]string{"set", "2.0.0"})
-			if err := cmd.RootCmd.Execute(); err != nil {
+	cmdtest.WithTempWD(t, func(tmp string) {
+		cmdtest.WriteVERSION(t, "1.2.3")
+		out := cmdtest.CaptureStdout(t, func() {
+			if err := cmdtest.Execute("set", "2.0.0"); err != nil {
 				t.Fatalf("execute: %v", err)
 			}
 		})
-		if got := readVERSION(t); got != "2.0.0" {
+		if got := cmdtest.ReadVERSION(t); got != "2.0.0" {
 			t.Fatalf("expected VERSION=2.0.0, got %q", got)
 		}
 		if !strings.Contains(out, "Current Version: 1.2.3") ||
@@ -75,16 +31,15 @@ func TestSetVersion_WritesAndPrints(t *testing.T) {
 }
 
 func TestSetVersion_DryRun(t *testing.T) {
-	withTempWD(t, func(tmp string) {
-		writeVERSION(t, "1.2.3")
-		out := captureStdout(t, func() {
-			cmd.RootCmd.SetArgs([]string{"set", "--dry", "2.1.0"})
-			if err := cmd.RootCmd.Execute(); err != nil {
+	cmdtest.WithTempWD(t, func(tmp string) {
+		cmdtest.WriteVERSION(t, "1.2.3")
+		out := cmdtest.CaptureStdout(t, func() {
+			if err := cmdtest.Execute("set", "--dry", "2.1.0"); err != nil {
 				t.Fatalf("execute: %v", err)
 			}
 		})
 		// Unchanged
-		if got := readVERSION(t); got != "1.2.3" {
+		if got := cmdtest.ReadVERSION(t); got != "1.2.3" {
 			t.Fatalf("expected VERSION unchanged, got %q", got)
 		}
 		if !strings.Contains(out, "Current Version: 1.2.3") ||
@@ -97,16 +52,15 @@ func TestSetVersion_DryRun(t *testing.T) {
 }
 
 func TestSetPre_SetAndClear(t *testing.T) {
-	withTempWD(t, func(tmp string) {
-		writeVERSION(t, "1.2.3")
+	cmdtest.WithTempWD(t, func(tmp string) {
+		cmdtest.WriteVERSION(t, "1.2.3")
 		// set pre
-		out := captureStdout(t, func() {
-			cmd.RootCmd.SetArgs([]string{"set", "pre", "--value", "rc.1", "--clear=false", "--dry=false"})
-			if err := cmd.RootCmd.Execute(); err != nil {
+		out := cmdtest.CaptureStdout(t, func() {
+			if err := cmdtest.Execute("set", "pre", "--value", "rc.1"); err != nil {
 				t.Fatalf("execute: %v", err)
 			}
 		})
-		if got := readVERSION(t); got != "1.2.3-rc.1" {
+		if got := cmdtest.ReadVERSION(t); got != "1.2.3-rc.1" {
 			t.Fatalf("expected VERSION=1.2.3-rc.1, got %q", got)
 		}
 		if !strings.Contains(out, "Setting Prerelease") ||
@@ -115,13 +69,12 @@ func TestSetPre_SetAndClear(t *testing.T) {
 		}
 
 		// clear pre
-		out = captureStdout(t, func() {
-			cmd.RootCmd.SetArgs([]string{"set", "pre", "--clear", "--value=", "--dry=false"})
-			if err := cmd.RootCmd.Execute(); err != nil {
+		out = cmdtest.CaptureStdout(t, func() {
+			if err := cmdtest.Execute("set", "pre", "--clear"); err != nil {
 				t.Fatalf("execute: %v", err)
 			}
 		})
-		if got := readVERSION(t); got != "1.2.3" {
+		if got := cmdtest.ReadVERSION(t); got != "1.2.3" {
 			t.Fatalf("expected VERSION=1.2.3 after clear, got %q", got)
 		}
 		if !strings.Contains(out, "Setting Prerelease") ||
@@ -132,15 +85,14 @@ func TestSetPre_SetAndClear(t *testing.T) {
 }
 
 func TestSetBuild_SetValue(t *testing.T) {
-	withTempWD(t, func(tmp string) {
-		writeVERSION(t, "1.2.3")
-		out := captureStdout(t, func() {
-			cmd.RootCmd.SetArgs([]string{"set", "build", "--value", "exp.7", "--git=false", "--clear=false", "--dry=false"})
-			if err := cmd.RootCmd.Execute(); err != nil {
+	cmdtest.WithTempWD(t, func(tmp string) {
+		cmdtest.WriteVERSION(t, "1.2.3")
+		out := cmdtest.CaptureStdout(t, func() {
+			if err := cmdtest.Execute("set", "build", "--value", "exp.7"); err != nil {
 				t.Fatalf("execute: %v", err)
 			}
 		})
-		if got := readVERSION(t); got != "1.2.3+exp.7" {
+		if got := cmdtest.ReadVERSION(t); got != "1.2.3+exp.7" {
 			t.Fatalf("expected VERSION=1.2.3+exp.7, got %q", got)
 		}
 		if !strings.Contains(out, "Setting Build Metadata") ||
@@ -151,15 +103,14 @@ func TestSetBuild_SetValue(t *testing.T) {
 }
 
 func TestSetBuild_Clear(t *testing.T) {
-	withTempWD(t, func(tmp string) {
-		writeVERSION(t, "1.2.3+exp.7")
-		out := captureStdout(t, func() {
-			cmd.RootCmd.SetArgs([]string{"set", "build", "--clear", "--value=", "--git=false", "--dry=false"})
-			if err := cmd.RootCmd.Execute(); err != nil {
+	cmdtest.WithTempWD(t, func(tmp string) {
+		cmdtest.WriteVERSION(t, "1.2.3+exp.7")
+		out := cmdtest.CaptureStdout(t, func() {
+			if err := cmdtest.Execute("set", "build", "--clear"); err != nil {
 				t.Fatalf("execute: %v", err)
 			}
 		})
-		if got := readVERSION(t); got != "1.2.3" {
+		if got := cmdtest.ReadVERSION(t); got != "1.2.3" {
 			t.Fatalf("expected VERSION=1.2.3 after clear, got %q", got)
 		}
 		if !strings.Contains(out, "Setting Build Metadata") ||
@@ -169,18 +120,222 @@ func TestSetBuild_Clear(t *testing.T) {
 	})
 }
 
+func TestSetBuild_RejectsInvalidValue(t *testing.T) {
+	cmdtest.WithTempWD(t, func(tmp string) {
+		cmdtest.WriteVERSION(t, "1.2.3")
+		cmdtest.CaptureStdout(t, func() {
+			if err := cmdtest.Execute("set", "build", "--value", "exp..7"); err == nil {
+				t.Fatalf("expected an error for empty build identifier")
+			}
+		})
+		if got := cmdtest.ReadVERSION(t); got != "1.2.3" {
+			t.Fatalf("VERSION changed to %q", got)
+		}
+	})
+}
+
 func TestSet_NoVersionFile_Message(t *testing.T) {
-	withTempWD(t, func(tmp string) {
+	cmdtest.WithTempWD(t, func(tmp string) {
 		// Ensure no VERSION
 		if _, err := os.Stat(filepath.Join(tmp, "VERSION")); !os.IsNotExist(err) {
 			t.Fatalf("expected no VERSION file")
 		}
-		out := captureStdout(t, func() {
-			cmd.RootCmd.SetArgs([]string{"set", "2.0.0"})
-			_ = cmd.RootCmd.Execute() // prints message, returns nil
+		out := cmdtest.CaptureStdout(t, func() {
+			_ = cmdtest.Execute("set", "2.0.0") // prints message, returns nil
 		})
 		if !strings.Contains(out, "No VERSION file found") {
 			t.Fatalf("expected helpful message, got:\n%s", out)
 		}
 	})
 }
+
+func TestSetVersion_RejectsMalformed(t *testing.T) {
+	cmdtest.WithTempWD(t, func(tmp string) {
+		cmdtest.WriteVERSION(t, "1.2.3")
+		var err error
+		cmdtest.CaptureStdout(t, func() {
+			err = cmdtest.Execute("set", "garbage")
+		})
+		if err == nil {
+			t.Fatalf("expected error for malformed version")
+		}
+		if got := cmdtest.ReadVERSION(t); got != "1.2.3" {
+			t.Fatalf("expected VERSION unchanged, got %q", got)
+		}
+	})
+}
+
+func TestSetPre_RejectsInvalidPrerelease(t *testing.T) {
+	cmdtest.WithTempWD(t, func(tmp string) {
+		cmdtest.WriteVERSION(t, "1.2.3")
+		var err error
+		cmdtest.CaptureStdout(t, func() {
+			err = cmdtest.Execute("set", "pre", "--value", "rc..1")
+		})
+		if err == nil {
+			t.Fatalf("expected error for empty prerelease identifier")
+		}
+		if got := cmdtest.ReadVERSION(t); got != "1.2.3" {
+			t.Fatalf("expected VERSION unchanged, got %q", got)
+		}
+	})
+}
+
+func TestSetBuild_RejectsMalformedVersionFile(t *testing.T) {
+	cmdtest.WithTempWD(t, func(tmp string) {
+		cmdtest.WriteVERSION(t, "1.2")
+		var err error
+		cmdtest.CaptureStdout(t, func() {
+			err = cmdtest.Execute("set", "build", "--value", "exp.7")
+		})
+		if err == nil {
+			t.Fatalf("expected error for malformed VERSION")
+		}
+		if got := cmdtest.ReadVERSION(t); got != "1.2" {
+			t.Fatalf("expected VERSION unchanged, got %q", got)
+		}
+	})
+}
+
+func TestSetVersion_Loose(t *testing.T) {
+	cmdtest.WithTempWD(t, func(tmp string) {
+		cmdtest.WriteVERSION(t, "1.0.0")
+		if err := cmdtest.Execute("set", "--loose=false", "v1.2"); err == nil {
+			t.Fatalf("expected v1.2 to be rejected without --loose")
+		}
+		cmdtest.CaptureStdout(t, func() {
+			if err := cmdtest.Execute("set", "--loose", "v1.2"); err != nil {
+				t.Fatalf("execute: %v", err)
+			}
+		})
+		if got := cmdtest.ReadVERSION(t); got != "1.2.0" {
+			t.Fatalf("expected VERSION=1.2.0, got %q", got)
+		}
+	})
+}
+
+func TestSetVersion_FourPart(t *testing.T) {
+	cmdtest.WithTempWD(t, func(tmp string) {
+		cmdtest.WriteVERSION(t, "1.2.3")
+		cmdtest.CaptureStdout(t, func() {
+			if err := cmdtest.Execute("set", "1.2.3.4"); err != nil {
+				t.Fatalf("execute: %v", err)
+			}
+		})
+		if got := cmdtest.ReadVERSION(t); got != "1.2.3.4" {
+			t.Fatalf("expected VERSION=1.2.3.4, got %q", got)
+		}
+
+		cmdtest.CaptureStderr(t, func() {
+			cmdtest.CaptureStdout(t, func() {
+				if err := cmdtest.Execute("set", "--loose", "1.2.3.5"); err != nil {
+					t.Fatalf("execute: %v", err)
+				}
+			})
+		})
+		if got := cmdtest.ReadVERSION(t); got != "1.2.3" {
+			t.Fatalf("expected --loose to coerce to 1.2.3, got %q", got)
+		}
+	})
+}
+
+func TestSetVersion_PythonStore(t *testing.T) {
+	cmdtest.WithTempWD(t, func(tmp string) {
+		if err := os.MkdirAll("tool", 0o755); err != nil {
+			t.Fatal(err)
+		}
+		module := filepath.Join("tool", "__init__.py")
+		if err := os.WriteFile(module, []byte("__version__ = \"1.1.0\"\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		out := cmdtest.CaptureStdout(t, func() {
+			if err := cmdtest.Execute("--store", "python", "--file", module, "set", "1.2.0-rc.1"); err != nil {
+				t.Fatalf("execute: %v", err)
+			}
+		})
+		if b, _ := os.ReadFile(module); string(b) != "__version__ = \"1.2.0rc1\"\n" {
+			t.Fatalf("%s:\n%s", module, b)
+		}
+		if !strings.Contains(out, "Current Version: 1.1.0") || !strings.Contains(out, "New Version: 1.2.0-rc.1") {
+			t.Fatalf("unexpected stdout:\n%s", out)
+		}
+	})
+}
+
+func TestSetVersion_PythonStoreRejectsBeforeDryRun(t *testing.T) {
+	cmdtest.WithTempWD(t, func(tmp string) {
+		if err := os.WriteFile("pyproject.toml", []byte("[project]\nversion = \"2.0.0\"\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		for _, args := range [][]string{
+			{"--store", "python", "set", "--dry", "3.0.0-nightly"},
+			{"--store", "python", "set", "3.0.0-nightly"},
+		} {
+			var err error
+			out := cmdtest.CaptureStdout(t, func() {
+				err = cmdtest.Execute(args...)
+			})
+			if !errors.Is(err, types.ErrNotPEP440) {
+				t.Fatalf("%q: expected ErrNotPEP440, got %v", args, err)
+			}
+			if strings.Contains(out, "New Version") {
+				t.Fatalf("%q: reported a version that cannot be stored:\n%s", args, out)
+			}
+		}
+		if b, _ := os.ReadFile("pyproject.toml"); string(b) != "[project]\nversion = \"2.0.0\"\n" {
+			t.Fatalf("pyproject.toml changed:\n%s", b)
+		}
+	})
+}
+
+func TestSetVersion_ManifestFileSelectsItsStore(t *testing.T) {
+	cmdtest.WithTempWD(t, func(tmp string) {
+		pkg := "{\n  \"name\": \"web\",\n  \"version\": \"1.2.3\"\n}\n"
+		if err := os.WriteFile("package.json", []byte(pkg), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		cmdtest.CaptureStdout(t, func() {
+			if err := cmdtest.Execute("--file", "package.json", "set", "2.0.0"); err != nil {
+				t.Fatalf("execute: %v", err)
+			}
+		})
+		want := strings.Replace(pkg, "1.2.3", "2.0.0", 1)
+		if b, _ := os.ReadFile("package.json"); string(b) != want {
+			t.Fatalf("package.json = %q, want %q", b, want)
+		}
+
+		for _, args := range [][]string{
+			{"--store", "file", "--file", "package.json", "set", "3.0.0"},
+			{"--store", "cargo", "--file", "package.json", "set", "3.0.0"},
+		} {
+			if err := cmdtest.Execute(args...); err == nil || !strings.Contains(err.Error(), "--store npm") {
+				t.Fatalf("%q: expected an error naming --store npm, got %v", args, err)
+			}
+		}
+		if b, _ := os.ReadFile("package.json"); string(b) != want {
+			t.Fatalf("package.json changed by a rejected run: %q", b)
+		}
+	})
+}
+
+func TestSetVersion_RejectsFileWithoutVersion(t *testing.T) {
+	cmdtest.WithTempWD(t, func(tmp string) {
+		notes := "# Release notes\n\nNothing yet.\n"
+		if err := os.WriteFile("NOTES.md", []byte(notes), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		var err error
+		out := cmdtest.CaptureStdout(t, func() {
+			err = cmdtest.Execute("--file", "NOTES.md", "set", "2.0.0")
+		})
+		if err == nil || !strings.Contains(err.Error(), "does not hold a version") {
+			t.Fatalf("expected an error, got %v", err)
+		}
+		if strings.Contains(out, "Current Version") {
+			t.Fatalf("should not print the file as a version:\n%s", out)
+		}
+		if b, _ := os.ReadFile("NOTES.md"); string(b) != notes {
+			t.Fatalf("NOTES.md was overwritten: %q", b)
+		}
+	})
+}
@@ -0,0 +1,47 @@
+package sortcmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/dp1140a/semver/cmd"
+	"github.com/spf13/cobra"
+)
+
+var MaxCmd = &cobra.Command{
+	Use:   "max [version...]",
+	Short: "Print the version with the highest precedence",
+	Long:  "Print the highest of the versions given as arguments, or newline-separated on stdin.",
+	RunE: func(c *cobra.Command, args []string) error {
+		return runPick(c, args, entries.Max)
+	},
+}
+
+var MinCmd = &cobra.Command{
+	Use:   "min [version...]",
+	Short: "Print the version with the lowest precedence",
+	Long:  "Print the lowest of the versions given as arguments, or newline-separated on stdin.",
+	RunE: func(c *cobra.Command, args []string) error {
+		return runPick(c, args, entries.Min)
+	},
+}
+
+func init() {
+	cmd.RootCmd.AddCommand(MaxCmd)
+	cmd.RootCmd.AddCommand(MinCmd)
+	addListFlags(MaxCmd)
+	addListFlags(MinCmd)
+}
+
+func runPick(c *cobra.Command, args []string, pick func(entries) int) error {
+	list, err := readEntries(c, args)
+	if err != nil {
+		return err
+	}
+	i := pick(list)
+	if i < 0 {
+		return errors.New("no versions given")
+	}
+	fmt.Println(list.raw[i])
+	return nil
+}
@@ -0,0 +1,110 @@
+package sortcmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/dp1140a/semver/cmd"
+	"github.com/dp1140a/semver/pkg/cli"
+	"github.com/dp1140a/semver/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+var SortCmd = &cobra.Command{
+	Use:   "sort [version...]",
+	Short: "Sort versions by precedence",
+	Long: `Sort versions from the arguments, or newline-separated from stdin, in ascending SemVer precedence.
+Versions with equal precedence keep their input order. With --unique only the first of them is printed, so
+1.0.0+a and 1.0.0+b, which differ only in build metadata, print once.`,
+	RunE: func(c *cobra.Command, args []string) error {
+		reverse, _ := c.Flags().GetBool("reverse")
+		unique, _ := c.Flags().GetBool("unique")
+
+		list, err := readEntries(c, args)
+		if err != nil {
+			return err
+		}
+
+		if reverse {
+			sort.Stable(sort.Reverse(list))
+		} else {
+			sort.Stable(list)
+		}
+
+		for i, v := range list.Versions {
+			// the list is sorted, so versions of equal precedence are adjacent
+			if unique && i > 0 && v.Equal(list.Versions[i-1]) {
+				continue
+			}
+			fmt.Println(list.raw[i])
+		}
+		return nil
+	},
+}
+
+func init() {
+	cmd.RootCmd.AddCommand(SortCmd)
+	addListFlags(SortCmd)
+	SortCmd.Flags().BoolP("reverse", "r", false, "Sort in descending order")
+	SortCmd.Flags().BoolP("unique", "u", false, "Print only the first of versions with equal precedence")
+}
+
+// addListFlags adds the input flags shared by sort, max and min.
+func addListFlags(c *cobra.Command) {
+	c.Flags().Bool("drop-invalid", false, "Skip entries that are not valid versions instead of failing")
+	c.Flags().Bool("keep-prefix", false, "Keep a leading 'v' in the output")
+	cli.AddLooseFlag(c)
+}
+
+// entries pairs parsed versions with the text to print for each one, and
+// keeps the two in step while sorting.
+type entries struct {
+	types.Versions
+	raw []string
+}
+
+func (e entries) Swap(i, j int) {
+	e.Versions.Swap(i, j)
+	e.raw[i], e.raw[j] = e.raw[j], e.raw[i]
+}
+
+// readEntries parses the versions given as args or on stdin. The original
+// spelling is kept for output, minus any 'v' prefix unless --keep-prefix;
+// with --loose the coerced version is printed instead.
+func readEntries(c *cobra.Command, args []string) (entries, error) {
+	dropInvalid, _ := c.Flags().GetBool("drop-invalid")
+	keepPrefix, _ := c.Flags().GetBool("keep-prefix")
+	loose, _ := c.Flags().GetBool("loose")
+
+	lines, err := cli.ArgsOrLines(args, c.InOrStdin())
+	if err != nil {
+		return entries{}, err
+	}
+
+	var list entries
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		v, err := cli.ParseVersion(line, loose)
+		if err != nil {
+			if dropInvalid {
+				continue
+			}
+			return entries{}, err
+		}
+		prefix, rest := types.SplitPrefix(line)
+		switch {
+		case loose:
+			// print the coerced form, not the real-world spelling
+			line = v.String()
+			if keepPrefix {
+				line = prefix + line
+			}
+		case !keepPrefix:
+			line = rest
+		}
+		list.Versions = append(list.Versions, v)
+		list.raw = append(list.raw, line)
+	}
+	return list, nil
+}
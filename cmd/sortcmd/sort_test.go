@@ -0,0 +1,100 @@
+package sortcmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dp1140a/semver/cmd/internal/cmdtest"
+)
+
+const tags = `v1.10.0
+1.2.0
+v1.2.0-rc.1
+
+0.9.0
+1.2.0
+not-a-version
+V1.2.0-beta
+`
+
+func TestSort_Stdin(t *testing.T) {
+	out, err := cmdtest.Run(t, tags, "sort", "--drop-invalid")
+	if err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+	want := "0.9.0\n1.2.0-beta\n1.2.0-rc.1\n1.2.0\n1.2.0\n1.10.0\n"
+	if out != want {
+		t.Fatalf("got:\n%s\nwant:\n%s", out, want)
+	}
+}
+
+func TestSort_ReverseUniqueKeepPrefix(t *testing.T) {
+	out, err := cmdtest.Run(t, tags, "sort", "--reverse", "--unique", "--drop-invalid", "--keep-prefix")
+	if err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+	want := "v1.10.0\n1.2.0\nv1.2.0-rc.1\nV1.2.0-beta\n0.9.0\n"
+	if out != want {
+		t.Fatalf("got:\n%s\nwant:\n%s", out, want)
+	}
+}
+
+func TestSort_UniqueIgnoresBuild(t *testing.T) {
+	out, err := cmdtest.Run(t, "", "sort", "--unique", "1.0.0+b", "2.0.0", "1.0.0+a", "1.0.0")
+	if err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+	if want := "1.0.0+b\n2.0.0\n"; out != want {
+		t.Fatalf("got:\n%s\nwant:\n%s", out, want)
+	}
+}
+
+func TestSort_Args(t *testing.T) {
+	out, err := cmdtest.Run(t, "", "sort", "2.0.0", "1.0.0+b", "1.0.0+a")
+	if err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+	if want := "1.0.0+b\n1.0.0+a\n2.0.0\n"; out != want {
+		t.Fatalf("got:\n%s\nwant:\n%s", out, want)
+	}
+}
+
+func TestSort_InvalidFailsWithoutDrop(t *testing.T) {
+	_, err := cmdtest.Run(t, tags, "sort")
+	if err == nil || !strings.Contains(err.Error(), "not-a-version") {
+		t.Fatalf("expected error naming the invalid entry, got %v", err)
+	}
+}
+
+func TestMaxMin(t *testing.T) {
+	out, err := cmdtest.Run(t, tags, "max", "--drop-invalid", "--keep-prefix")
+	if err != nil || out != "v1.10.0\n" {
+		t.Fatalf("max = %q (%v), want v1.10.0", out, err)
+	}
+	out, err = cmdtest.Run(t, tags, "max", "--drop-invalid")
+	if err != nil || out != "1.10.0\n" {
+		t.Fatalf("max = %q (%v), want 1.10.0", out, err)
+	}
+	out, err = cmdtest.Run(t, "", "min", "1.0.0", "1.0.0-rc.1", "1.1.0")
+	if err != nil || out != "1.0.0-rc.1\n" {
+		t.Fatalf("min = %q (%v), want 1.0.0-rc.1", out, err)
+	}
+}
+
+func TestMax_Empty(t *testing.T) {
+	if _, err := cmdtest.Run(t, "garbage\n", "max", "--drop-invalid"); err == nil {
+		t.Fatalf("expected error when no valid versions are given")
+	}
+}
+
+func TestSort_Loose(t *testing.T) {
+	out, err := cmdtest.Run(t, "v1.10\nrelease-1.2.3\n1.2.3.4_rc\n",
+		"sort", "--keep-prefix", "--loose")
+	if err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+	want := "1.2.3-rc\n1.2.3\nv1.10.0\n"
+	if out != want {
+		t.Fatalf("got:\n%s\nwant:\n%s", out, want)
+	}
+}
@@ -6,7 +6,11 @@ package main
 import (
 	"github.com/dp1140a/semver/cmd"
 	_ "github.com/dp1140a/semver/cmd/bump"
+	_ "github.com/dp1140a/semver/cmd/compare"
+	_ "github.com/dp1140a/semver/cmd/release"
+	_ "github.com/dp1140a/semver/cmd/satisfies"
 	_ "github.com/dp1140a/semver/cmd/set"
+	_ "github.com/dp1140a/semver/cmd/sortcmd"
 	_ "github.com/dp1140a/semver/cmd/version"
 )
 
@@ -1,39 +1,114 @@
 package cli
 
 import (
+	"bufio"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
+
+	"github.com/dp1140a/semver/pkg/store"
+	"github.com/dp1140a/semver/pkg/types"
+	"github.com/spf13/cobra"
 )
 
-// ReadVersion reads VERSION and returns the trimmed string.
-// If the file doesn’t exist, returns ("", nil) so callers can print a helpful message.
+// ReadVersion reads the version from the selected store.
+// If there is none yet, returns ("", nil) so callers can print a helpful message.
+// A manifest without a version field is an error (store.ErrNoVersionField),
+// since init cannot fix it.
 func ReadVersion() (string, error) {
-	b, err := os.ReadFile("VERSION")
-	if err != nil {
-		if os.IsNotExist(err) {
-			return "", nil
-		}
-		return "", err
+	v, err := Store().Read()
+	if errors.Is(err, store.ErrNotFound) {
+		return "", nil
 	}
-	return strings.TrimSpace(string(b)), nil
+	return v, err
 }
 
-// WriteVersion atomically writes VERSION with a trailing newline.
+// WriteVersion writes v to the selected store.
 func WriteVersion(v string) error {
-	tmp := filepath.Join(".", ".VERSION.tmp")
-	if err := os.WriteFile(tmp, []byte(v+"\n"), 0o644); err != nil {
-		return err
-	}
-	return os.Rename(tmp, "VERSION")
+	return Store().Write(v)
 }
 
 func PrintNoVersionMsg(cwd string) {
-	fmt.Printf("No VERSION file found in %v.\nPlease either change directory or first run 'semver init'\n", cwd)
+	current := Store()
+	if f, ok := current.(*store.File); ok {
+		dir := filepath.Dir(f.Path)
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(cwd, dir)
+		}
+		fmt.Printf("No %s file found in %v.\nPlease either change directory or first run 'semver init'\n", filepath.Base(f.Path), dir)
+		return
+	}
+	// init cannot create a manifest, so do not suggest it
+	fmt.Printf("No %s found.\nPlease either change directory or point --file or --dir at it\n", current.Describe())
+}
+
+// ApplyVersion ends a command that computed next. With dry set it prints
+// what would be written; otherwise it writes next and only then reports the
+// new version, so a failed write never claims one. Either way a version the
+// store cannot hold (see store.Checker) is an error.
+func ApplyVersion(next string, dry bool) error {
+	if c, ok := Store().(store.Checker); ok {
+		if err := c.Check(next); err != nil {
+			return fmt.Errorf("%s: %w", Store().Describe(), err)
+		}
+	}
+	if dry {
+		RenderDry(next)
+		return nil
+	}
+	if err := WriteVersion(next); err != nil {
+		return err
+	}
+	fmt.Printf("New Version: %s\n", next)
+	return nil
 }
 
 // RenderDry prints a standardized dry-run message.
 func RenderDry(next string) {
-	fmt.Printf("[dry-run] New Version would be: %s (VERSION file unchanged)\n", next)
+	what := Store().Describe()
+	if f, ok := Store().(*store.File); ok {
+		what = filepath.Base(f.Path) + " file"
+	}
+	fmt.Printf("[dry-run] New Version would be: %s (%s unchanged)\n", next, what)
+}
+
+// ArgsOrLines returns args when any were given, otherwise the trimmed,
+// non-empty lines read from r (usually stdin).
+func ArgsOrLines(args []string, r io.Reader) ([]string, error) {
+	if len(args) > 0 {
+		return args, nil
+	}
+	var lines []string
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		if line := strings.TrimSpace(sc.Text()); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines, sc.Err()
+}
+
+// ParseVersion parses a version given on the command line. With loose set it
+// uses types.Coerce instead of types.Parse and reports on stderr anything
+// the coercion changed or dropped.
+func ParseVersion(s string, loose bool) (types.Version, error) {
+	if !loose {
+		return types.Parse(s)
+	}
+	v, notes, err := types.Coerce(s)
+	if err != nil {
+		return types.Version{}, err
+	}
+	if len(notes) > 0 {
+		fmt.Fprintf(os.Stderr, "Coerced %q to %s: %s\n", s, v.String(), strings.Join(notes, "; "))
+	}
+	return v, nil
+}
+
+// AddLooseFlag adds the --loose flag read by ParseVersion callers.
+func AddLooseFlag(c *cobra.Command) {
+	c.Flags().Bool("loose", false, "Coerce real-world strings like v1.2, 1.2.3.4 or release-1.2.3 into a valid version")
 }
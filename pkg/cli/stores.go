@@ -0,0 +1,151 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/dp1140a/semver/pkg/store"
+)
+
+// storeKind is a backend that --store can select.
+type storeKind struct {
+	file string // name of the file it reads, looked for by discovery
+	open func(path string) store.VersionStore
+}
+
+var storeKinds = map[string]storeKind{
+	"file":   {store.FileName, func(path string) store.VersionStore { return store.NewFile(path) }},
+	"cargo":  {"Cargo.toml", func(path string) store.VersionStore { return store.NewCargo(path) }},
+	"npm":    {"package.json", func(path string) store.VersionStore { return store.NewNPM(path) }},
+	"python": {"pyproject.toml", func(path string) store.VersionStore { return store.NewPython(path) }},
+}
+
+// StoreKinds lists the values SelectStore accepts, sorted.
+func StoreKinds() []string {
+	kinds := make([]string, 0, len(storeKinds))
+	for k := range storeKinds {
+		kinds = append(kinds, k)
+	}
+	sort.Strings(kinds)
+	return kinds
+}
+
+var (
+	// current is the store every command reads and writes; see SetStore.
+	current store.VersionStore
+	// explicit is set while current came from SetStore, which SelectStore
+	// keeps unless a flag or $SEMVER_FILE names another store.
+	explicit bool
+	// kind is what Store discovers when no store has been selected.
+	kind = storeKinds["file"]
+)
+
+// Store returns the selected version store. By default that is the VERSION
+// file found by store.Discover from the working directory, or ./VERSION if
+// there is none; a file found in a parent directory is reported on stderr.
+func Store() store.VersionStore {
+	if current == nil {
+		current = discover()
+	}
+	return current
+}
+
+// SetStore selects the store that ReadVersion and WriteVersion use. It stays
+// selected across SelectStore calls that name no store. nil restores
+// discovery of the kind last passed to SelectStore.
+func SetStore(s store.VersionStore) {
+	current = s
+	explicit = s != nil
+}
+
+// SelectStore picks the store for a run from the global flags: name is a
+// StoreKinds value ("" means not given), file is --file and dir is --dir. The
+// path is --file (relative to --dir if both are given), then the kind's file
+// in --dir, then $SEMVER_FILE; without any of them the file is discovered on
+// first use, up to the git root. When none of them is given a store set with
+// SetStore is kept.
+//
+// A manifest named by --file or $SEMVER_FILE selects its own kind, so
+// --file package.json reads the npm store; naming another kind for it is an
+// error rather than a way to overwrite the manifest with a bare version.
+// Without a manifest name or a kind the store is "file".
+func SelectStore(name, file, dir string) error {
+	env := os.Getenv("SEMVER_FILE")
+	if explicit && name == "" && file == "" && dir == "" && env == "" {
+		return nil
+	}
+	if file == "" && dir == "" {
+		file = env
+	}
+	if file != "" {
+		var err error
+		if name, err = kindForFile(file, name); err != nil {
+			return err
+		}
+	}
+	if name == "" {
+		name = "file"
+	}
+	k, ok := storeKinds[name]
+	if !ok {
+		return fmt.Errorf("unknown store %q; choose one of %s", name, strings.Join(StoreKinds(), ", "))
+	}
+	switch {
+	case file != "":
+		if dir != "" && !filepath.IsAbs(file) {
+			file = filepath.Join(dir, file)
+		}
+	case dir != "":
+		file = filepath.Join(dir, k.file)
+	}
+
+	kind = k
+	current, explicit = nil, false
+	if file != "" {
+		current = k.open(file)
+	}
+	return nil
+}
+
+// manifestKinds maps the manifests the stores read to their kind.
+var manifestKinds = map[string]string{
+	"package.json":   "npm",
+	"Cargo.toml":     "cargo",
+	"pyproject.toml": "python",
+	"setup.cfg":      "python",
+}
+
+// kindForFile returns the store kind for path: the manifest's own kind if
+// path names one, otherwise name.
+func kindForFile(path, name string) (string, error) {
+	base := filepath.Base(path)
+	if base == "go.mod" {
+		return "", fmt.Errorf("%s holds no version; Go modules are versioned by their git tags", path)
+	}
+	want, ok := manifestKinds[base]
+	switch {
+	case !ok:
+		return name, nil
+	case name == "":
+		return want, nil
+	case name != want:
+		return "", fmt.Errorf("%s is read by --store %s, not --store %s", path, want, name)
+	}
+	return name, nil
+}
+
+func discover() store.VersionStore {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return kind.open(kind.file)
+	}
+	path, err := store.Find(cwd, kind.file)
+	if err != nil || path == "" || filepath.Dir(path) == cwd {
+		return kind.open(kind.file)
+	}
+	fmt.Fprintf(os.Stderr, "Using %s\n", path)
+	return kind.open(path)
+}
@@ -0,0 +1,77 @@
+package constraint
+
+import (
+	"strings"
+
+	"github.com/dp1140a/semver/pkg/types"
+)
+
+type operator int
+
+const (
+	opAny operator = iota
+	opEQ
+	opLT
+	opLTE
+	opGT
+	opGTE
+)
+
+var operators = map[string]operator{
+	"=":  opEQ,
+	"<":  opLT,
+	"<=": opLTE,
+	">":  opGT,
+	">=": opGTE,
+}
+
+func (op operator) String() string {
+	switch op {
+	case opEQ:
+		return "="
+	case opLT:
+		return "<"
+	case opLTE:
+		return "<="
+	case opGT:
+		return ">"
+	case opGTE:
+		return ">="
+	}
+	return "*"
+}
+
+// comparator is a single primitive test such as ">=1.2.0".
+type comparator struct {
+	op      operator
+	version types.Version
+}
+
+func (c comparator) matches(v types.Version) bool {
+	cmp := v.Compare(c.version)
+	switch c.op {
+	case opEQ:
+		return cmp == 0
+	case opLT:
+		return cmp < 0
+	case opLTE:
+		return cmp <= 0
+	case opGT:
+		return cmp > 0
+	case opGTE:
+		return cmp >= 0
+	}
+	return true
+}
+
+func (c comparator) String() string {
+	if c.op == opAny {
+		return "*"
+	}
+	return c.op.String() + c.version.String()
+}
+
+// parsePartial parses the version part of a term; "=1.2" is read as "1.2".
+func parsePartial(s string) (types.Partial, error) {
+	return types.ParsePartial(strings.TrimPrefix(s, "="))
+}
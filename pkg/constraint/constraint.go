@@ -0,0 +1,369 @@
+package constraint
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/dp1140a/semver/pkg/types"
+)
+
+// ErrInvalid is wrapped by every error Parse returns.
+var ErrInvalid = errors.New("invalid constraint")
+
+// Constraint is a version range expression such as ">=1.2.0 <2.0.0 || ^3.1".
+// Ranges separated by "||" are alternatives; within a range every comparator
+// must hold. A version satisfies the constraint if it satisfies any range.
+type Constraint struct {
+	raw    string
+	ranges [][]comparator
+}
+
+// Parse parses a range expression using npm's syntax: primitive comparators
+// (<, <=, >, >=, =), hyphen ranges (1.2.3 - 2.3.4), x-ranges (1.x, 1.2.*, *),
+// partial versions (1, 1.2), tilde ranges (~1.2.3) and caret ranges (^1.2.3),
+// joined by whitespace (and) and "||" (or).
+func Parse(s string) (*Constraint, error) {
+	c := &Constraint{raw: s}
+	for _, r := range strings.Split(s, "||") {
+		comps, err := parseRange(r)
+		if err != nil {
+			return nil, fmt.Errorf("%w %q: %w", ErrInvalid, s, err)
+		}
+		c.ranges = append(c.ranges, comps)
+	}
+	return c, nil
+}
+
+// MustParse is like Parse but panics if the constraint cannot be parsed.
+func MustParse(s string) *Constraint {
+	c, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// Check reports whether v satisfies the constraint.
+//
+// As in npm, a prerelease version only satisfies a range if one of the
+// range's comparators names a prerelease of the same major.minor.patch, so
+// ">=1.2.3-beta.1" matches "1.2.3-beta.2" but not "1.2.4-beta.1".
+func (c *Constraint) Check(v types.Version) bool {
+	for _, r := range c.ranges {
+		if checkRange(r, v) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Constraint) String() string {
+	return c.raw
+}
+
+// Set parses s into c, so that *Constraint implements pflag.Value and
+// flag.Value. The error is the same one `semver satisfies` reports.
+func (c *Constraint) Set(s string) error {
+	parsed, err := Parse(s)
+	if err != nil {
+		return err
+	}
+	*c = *parsed
+	return nil
+}
+
+// Type names the value in pflag's usage output.
+func (c *Constraint) Type() string {
+	return "range"
+}
+
+func checkRange(r []comparator, v types.Version) bool {
+	for _, comp := range r {
+		if !comp.matches(v) {
+			return false
+		}
+	}
+	return allowsPrerelease(r, v)
+}
+
+// allowsPrerelease applies npm's prerelease-inclusion rule to a range whose
+// comparators all matched.
+func allowsPrerelease(r []comparator, v types.Version) bool {
+	if !v.IsPrerelease() {
+		return true
+	}
+	for _, comp := range r {
+		if comp.op == opAny || !comp.version.IsPrerelease() {
+			continue
+		}
+		if comp.version.Major == v.Major && comp.version.Minor == v.Minor && comp.version.Patch == v.Patch {
+			return true
+		}
+	}
+	return false
+}
+
+func parseRange(r string) ([]comparator, error) {
+	fields := joinOperators(strings.Fields(r))
+	switch {
+	case len(fields) == 0:
+		return []comparator{{op: opAny}}, nil
+	case len(fields) == 3 && fields[1] == "-":
+		return hyphenRange(fields[0], fields[2])
+	}
+
+	var comps []comparator
+	for _, f := range fields {
+		cs, err := parseTerm(f)
+		if err != nil {
+			return nil, err
+		}
+		comps = append(comps, cs...)
+	}
+	return comps, nil
+}
+
+// joinOperators glues a bare operator to the version that follows it, so
+// ">= 1.2.3" is read the same as ">=1.2.3".
+func joinOperators(fields []string) []string {
+	out := make([]string, 0, len(fields))
+	for i := 0; i < len(fields); i++ {
+		f := fields[i]
+		if isOperator(f) && i+1 < len(fields) {
+			f += fields[i+1]
+			i++
+		}
+		out = append(out, f)
+	}
+	return out
+}
+
+func isOperator(s string) bool {
+	switch s {
+	case "<", "<=", ">", ">=", "=", "~", "~>", "^":
+		return true
+	}
+	return false
+}
+
+// parseTerm desugars a single term into primitive comparators.
+func parseTerm(term string) ([]comparator, error) {
+	prefix, rest := splitOperator(term)
+	p, err := parsePartial(rest)
+	if err != nil {
+		return nil, fmt.Errorf("term %q: %w", term, err)
+	}
+
+	switch prefix {
+	case "~", "~>":
+		return tildeRange(p)
+	case "^":
+		return caretRange(p)
+	case "", "=":
+		return xRange(p)
+	}
+	return primitive(prefix, p)
+}
+
+func splitOperator(term string) (string, string) {
+	for _, op := range []string{"~>", ">=", "<=", "~", "^", ">", "<", "="} {
+		if strings.HasPrefix(term, op) {
+			return op, term[len(op):]
+		}
+	}
+	return "", term
+}
+
+// xRange handles bare and "=" terms: 1.2.3 is exact, 1.2 and 1.2.x cover
+// the minor line, 1 and 1.x cover the major line and * matches anything.
+func xRange(p types.Partial) ([]comparator, error) {
+	if p.Given() == 3 {
+		return []comparator{{op: opEQ, version: p.Version()}}, nil
+	}
+	return lineRange(p)
+}
+
+// tildeRange allows patch-level changes when a minor version is given and
+// minor-level changes otherwise.
+func tildeRange(p types.Partial) ([]comparator, error) {
+	if p.Given() < 3 {
+		return lineRange(p)
+	}
+	upper, err := bumped(p, 2)
+	if err != nil {
+		return nil, err
+	}
+	return []comparator{{op: opGTE, version: p.Version()}, {op: opLT, version: upper}}, nil
+}
+
+// caretRange allows changes that do not modify the left-most non-zero
+// component (or the last given component when all are zero).
+func caretRange(p types.Partial) ([]comparator, error) {
+	digits, v := p.Given(), p.Version()
+	switch {
+	case digits >= 1 && v.Major != 0:
+		digits = 1
+	case digits >= 2 && v.Minor != 0:
+		digits = 2
+	}
+	if digits == 0 {
+		return []comparator{{op: opAny}}, nil
+	}
+	upper, err := bumped(p, digits)
+	if err != nil {
+		return nil, err
+	}
+	return []comparator{{op: opGTE, version: p.Version()}, {op: opLT, version: upper}}, nil
+}
+
+// primitive handles <, <=, > and >= against a possibly partial version.
+func primitive(op string, p types.Partial) ([]comparator, error) {
+	if p.Given() == 3 {
+		return []comparator{{op: operators[op], version: p.Version()}}, nil
+	}
+	if p.Given() == 0 {
+		if op == "<" || op == ">" {
+			return []comparator{{op: opLT, version: lowest}}, nil // matches nothing
+		}
+		return []comparator{{op: opAny}}, nil
+	}
+
+	switch op {
+	case ">":
+		// >1.2 means anything past the 1.2 line
+		upper, err := bumped(p, p.Given())
+		if err != nil {
+			return nil, err
+		}
+		upper.PreRelease = ""
+		return []comparator{{op: opGTE, version: upper}}, nil
+	case "<=":
+		upper, err := bumped(p, p.Given())
+		if err != nil {
+			return nil, err
+		}
+		return []comparator{{op: opLT, version: upper}}, nil
+	case "<":
+		v := p.Version()
+		v.PreRelease = zeroPre
+		return []comparator{{op: opLT, version: v}}, nil
+	}
+	return []comparator{{op: opGTE, version: p.Version()}}, nil
+}
+
+// hyphenRange handles "A - B": inclusive at both ends, with a partial upper
+// bound covering its whole line.
+func hyphenRange(from, to string) ([]comparator, error) {
+	lo, err := parsePartial(from)
+	if err != nil {
+		return nil, fmt.Errorf("term %q: %w", from, err)
+	}
+	hi, err := parsePartial(to)
+	if err != nil {
+		return nil, fmt.Errorf("term %q: %w", to, err)
+	}
+
+	var comps []comparator
+	if lo.Given() > 0 {
+		comps = append(comps, comparator{op: opGTE, version: lo.Version()})
+	}
+	switch {
+	case hi.Given() == 3:
+		comps = append(comps, comparator{op: opLTE, version: hi.Version()})
+	case hi.Given() > 0:
+		upper, err := bumped(hi, hi.Given())
+		if err != nil {
+			return nil, err
+		}
+		comps = append(comps, comparator{op: opLT, version: upper})
+	}
+	if len(comps) == 0 {
+		comps = append(comps, comparator{op: opAny})
+	}
+	return comps, nil
+}
+
+// lineRange covers every version that starts with the components given in
+// the partial p: >=p <(p with its last given component bumped)-0.
+func lineRange(p types.Partial) ([]comparator, error) {
+	r, err := p.Range()
+	if err != nil {
+		return nil, err
+	}
+	var comps []comparator
+	if r.Lower != nil {
+		comps = append(comps, comparator{op: opGTE, version: *r.Lower})
+	}
+	if r.Upper != nil {
+		comps = append(comps, comparator{op: opLT, version: *r.Upper})
+	}
+	if len(comps) == 0 {
+		comps = append(comps, comparator{op: opAny})
+	}
+	return comps, nil
+}
+
+// bumped increments component n (1 = major, 2 = minor, 3 = patch) of p and
+// returns the lowest prerelease of the result, e.g. 1.2 bumped at 2 is 1.3.0-0.
+func bumped(p types.Partial, n int) (types.Version, error) {
+	core := p.Version()
+	v := types.Version{Major: core.Major, Minor: core.Minor, Patch: core.Patch}
+	var err error
+	switch n {
+	case 1:
+		err = v.IncrementMajor()
+	case 2:
+		err = v.IncrementMinor()
+	default:
+		err = v.IncrementPatch()
+	}
+	if err != nil {
+		return types.Version{}, err
+	}
+	v.PreRelease = zeroPre
+	return v, nil
+}
+
+// lowest is the smallest possible version; nothing is less than it.
+var lowest = types.Version{PreRelease: zeroPre}
+
+// zeroPre is the lowest prerelease, so X.Y.Z-0 sorts before every other
+// version on the X.Y.Z line.
+const zeroPre = "0"
+
+// Validate is like Check but also explains a failure: it returns one error
+// per range naming the first comparator v failed, or noting that v is a
+// prerelease the range does not allow.
+func (c *Constraint) Validate(v types.Version) (bool, []error) {
+	var errs []error
+	for _, r := range c.ranges {
+		err := validateRange(r, v)
+		if err == nil {
+			return true, nil
+		}
+		errs = append(errs, err)
+	}
+	return false, errs
+}
+
+func validateRange(r []comparator, v types.Version) error {
+	for _, comp := range r {
+		if !comp.matches(v) {
+			return fmt.Errorf("%s does not satisfy %s (range %s)", v.String(), comp, rangeString(r))
+		}
+	}
+	if !allowsPrerelease(r, v) {
+		return fmt.Errorf("%s is a prerelease and no comparator in range %s allows prereleases of %d.%d.%d",
+			v.String(), rangeString(r), v.Major, v.Minor, v.Patch)
+	}
+	return nil
+}
+
+func rangeString(r []comparator) string {
+	parts := make([]string, len(r))
+	for i, comp := range r {
+		parts[i] = comp.String()
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,203 @@
+package constraint
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dp1140a/semver/pkg/types"
+	"github.com/spf13/pflag"
+)
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		constraint string
+		version    string
+		want       bool
+	}{
+		// primitives
+		{">=1.2.0 <2.0.0", "1.2.0", true},
+		{">=1.2.0 <2.0.0", "1.9.9", true},
+		{">=1.2.0 <2.0.0", "2.0.0", false},
+		{">=1.2.0 <2.0.0", "1.1.9", false},
+		{">= 1.2.0", "1.2.0", true},
+		{">1.2.3", "1.2.3", false},
+		{"<=1.2.3", "1.2.3", true},
+		{"=1.2.3", "1.2.3+build.5", true},
+		{"1.2.3", "1.2.4", false},
+		{"v1.2.3", "1.2.3", true},
+
+		// partial primitives
+		{">1.2", "1.2.9", false},
+		{">1.2", "1.3.0", true},
+		{">1", "2.0.0", true},
+		{"<1.2", "1.1.9", true},
+		{"<1.2", "1.2.0", false},
+		{"<=1.2", "1.2.9", true},
+		{"<=1.2", "1.3.0", false},
+		{">=1.2", "1.2.0", true},
+		{"<*", "0.0.0", false},
+
+		// x-ranges
+		{"*", "3.4.5", true},
+		{"", "3.4.5", true},
+		{"1.x", "1.9.0", true},
+		{"1.x", "2.0.0", false},
+		{"1", "1.0.0", true},
+		{"1.2.*", "1.2.7", true},
+		{"1.2.*", "1.3.0", false},
+		{"1.2", "1.2.0", true},
+		{"1.X.x", "1.4.2", true},
+
+		// tilde
+		{"~1.4.2", "1.4.9", true},
+		{"~1.4.2", "1.4.1", false},
+		{"~1.4.2", "1.5.0", false},
+		{"~1.4", "1.4.0", true},
+		{"~1", "1.9.0", true},
+		{"~1", "2.0.0", false},
+		{"~>1.4.2", "1.4.3", true},
+
+		// caret
+		{"^1.4", "1.9.9", true},
+		{"^1.4", "1.3.0", false},
+		{"^1.4", "2.0.0", false},
+		{"^1.2.3", "1.8.0", true},
+		{"^0.2.3", "0.2.9", true},
+		{"^0.2.3", "0.3.0", false},
+		{"^0.0.3", "0.0.3", true},
+		{"^0.0.3", "0.0.4", false},
+		{"^0.0", "0.0.9", true},
+		{"^0.0", "0.1.0", false},
+		{"^0.x", "0.9.0", true},
+		{"^0.x", "1.0.0", false},
+		{"^1.x", "1.5.0", true},
+
+		// hyphen ranges
+		{"1.2.3 - 2.3.4", "2.3.4", true},
+		{"1.2.3 - 2.3.4", "2.3.5", false},
+		{"1.2 - 2.3.4", "1.2.0", true},
+		{"1.2.3 - 2.3", "2.3.9", true},
+		{"1.2.3 - 2.3", "2.4.0", false},
+		{"1.2.3 - 2", "2.9.9", true},
+
+		// alternation
+		{"1.x || >=2.5.0", "2.5.1", true},
+		{"1.x || >=2.5.0", "2.4.0", false},
+		{"^1.0.0||^3.0.0", "3.1.0", true},
+
+		// prerelease inclusion
+		{">=1.2.3-beta.1", "1.2.3-beta.2", true},
+		{">=1.2.3-beta.1", "1.2.4-beta.1", false},
+		{">=1.2.3-beta.1", "1.2.4", true},
+		{"^1.2.3-rc.1", "1.2.3-rc.2", true},
+		{"^1.2.3-rc.1", "1.3.0-rc.1", false},
+		{"*", "1.0.0-beta", false},
+		{"1.x", "1.1.0-alpha", false},
+		{"<2.0.0", "2.0.0-rc.1", false},
+		{"~1.2.3-beta.2", "1.2.3-beta.4", true},
+		{"~1.2.3-beta.2", "1.2.4-beta.2", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.constraint+"_"+tt.version, func(t *testing.T) {
+			c, err := Parse(tt.constraint)
+			if err != nil {
+				t.Fatalf("Parse(%q): %v", tt.constraint, err)
+			}
+			if got := c.Check(types.MustParse(tt.version)); got != tt.want {
+				t.Fatalf("%q.Check(%s) = %v, want %v", tt.constraint, tt.version, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	tests := []string{
+		">=foo",
+		"1.2.3.4",
+		"^01.2",
+		"1.x.3",
+		">=1.2.3 <",
+		"~1.2.3-",
+		"1.2.3 - nope",
+	}
+	for _, tt := range tests {
+		t.Run(tt, func(t *testing.T) {
+			if _, err := Parse(tt); !errors.Is(err, ErrInvalid) {
+				t.Fatalf("Parse(%q) = %v, want ErrInvalid", tt, err)
+			}
+		})
+	}
+}
+
+func TestString_ReturnsInput(t *testing.T) {
+	if got := MustParse(">=1.2.0 <2.0.0").String(); got != ">=1.2.0 <2.0.0" {
+		t.Fatalf("String() = %q", got)
+	}
+}
+
+func TestValidate_Explains(t *testing.T) {
+	c := MustParse(">=2.0.0 <3.0.0 || ^1.5")
+	ok, errs := c.Validate(types.MustParse("1.4.0"))
+	if ok || len(errs) != 2 {
+		t.Fatalf("expected two range failures, got ok=%v errs=%v", ok, errs)
+	}
+	if got := errs[0].Error(); got != "1.4.0 does not satisfy >=2.0.0 (range >=2.0.0 <3.0.0)" {
+		t.Fatalf("unexpected first reason: %s", got)
+	}
+	if got := errs[1].Error(); got != "1.4.0 does not satisfy >=1.5.0 (range >=1.5.0 <2.0.0-0)" {
+		t.Fatalf("unexpected second reason: %s", got)
+	}
+
+	ok, errs = c.Validate(types.MustParse("1.6.0"))
+	if !ok || errs != nil {
+		t.Fatalf("expected 1.6.0 to satisfy, got ok=%v errs=%v", ok, errs)
+	}
+}
+
+func TestConstraint_FlagValue(t *testing.T) {
+	fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	var c Constraint
+	fs.Var(&c, "range", "supported versions")
+
+	if err := fs.Parse([]string{"--range", "^1.4 || 2.x"}); err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if c.String() != "^1.4 || 2.x" || !c.Check(types.MustParse("2.1.0")) || c.Check(types.MustParse("1.3.0")) {
+		t.Fatalf("unexpected constraint %q", c.String())
+	}
+	if got := fs.Lookup("range").Value.Type(); got != "range" {
+		t.Fatalf("Type() = %q", got)
+	}
+
+	if err := fs.Parse([]string{"--range", ">=foo"}); !errors.Is(err, ErrInvalid) {
+		t.Fatalf("expected ErrInvalid, got %v", err)
+	}
+	if c.String() != "^1.4 || 2.x" {
+		t.Fatalf("invalid value changed the flag: %q", c.String())
+	}
+}
+
+// FuzzParse checks that Parse never panics, that every error wraps
+// ErrInvalid, and that a parsed constraint can be checked and validated.
+func FuzzParse(f *testing.F) {
+	for _, s := range []string{">=1.2.0 <2.0.0", "^1.4 || 2.x", "~1.2.3-beta.2", "1.2.3 - 2", "<*", "1.x.3", ">= 1.2.3 <"} {
+		f.Add(s, "1.2.3")
+	}
+	f.Fuzz(func(t *testing.T, s, version string) {
+		c, err := Parse(s)
+		if err != nil {
+			if !errors.Is(err, ErrInvalid) {
+				t.Fatalf("Parse(%q) = %v, want ErrInvalid", s, err)
+			}
+			return
+		}
+		v, err := types.Parse(version)
+		if err != nil {
+			return
+		}
+		ok, errs := c.Validate(v)
+		if ok != c.Check(v) || ok != (len(errs) == 0) {
+			t.Fatalf("%q on %s: Check=%v, Validate=%v %v", s, version, c.Check(v), ok, errs)
+		}
+	})
+}
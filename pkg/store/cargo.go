@@ -0,0 +1,151 @@
+package store
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Cargo stores the version in a Rust Cargo.toml: [package].version, or
+// [workspace.package].version for a workspace whose crates inherit it.
+// Writes replace only the quoted value, so comments and formatting are kept,
+// and update the matching [[package]] entries in Cargo.lock, which for a
+// workspace member lives at the workspace root: the crate itself, or for a
+// workspace version every local crate (one without a source) at the old
+// version.
+type Cargo struct {
+	Path string // path of Cargo.toml
+}
+
+// NewCargo returns a store for the Cargo.toml at path.
+func NewCargo(path string) *Cargo {
+	return &Cargo{Path: path}
+}
+
+// lockPath finds Cargo.lock next to Cargo.toml or in a parent directory up to
+// the git root, returning "" if there is none.
+func (c *Cargo) lockPath() (string, error) {
+	return Find(filepath.Dir(c.Path), "Cargo.lock")
+}
+
+// cargoManifest is what Read and Write need from Cargo.toml.
+type cargoManifest struct {
+	lines   []string
+	version lineValue
+	name    string // [package].name, "" for a workspace version
+}
+
+func (c *Cargo) load() (cargoManifest, error) {
+	data, err := os.ReadFile(c.Path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return cargoManifest{}, fmt.Errorf("%s: %w", c.Path, ErrNotFound)
+		}
+		return cargoManifest{}, err
+	}
+
+	m := cargoManifest{lines: strings.SplitAfter(string(data), "\n")}
+	if name, ok := findInSection(m.lines, "package", tomlName); ok {
+		m.name = name.value
+	}
+	pkg, inPkg := findInSection(m.lines, "package", tomlVersion)
+	ws, inWs := findInSection(m.lines, "workspace.package", tomlVersion)
+
+	switch {
+	case inPkg:
+		m.version = pkg
+	case inWs:
+		m.version, m.name = ws, ""
+	default:
+		return cargoManifest{}, fmt.Errorf("%s has %w in [package] or [workspace.package]", c.Path, ErrNoVersionField)
+	}
+	return m, nil
+}
+
+func (c *Cargo) Read() (string, error) {
+	m, err := c.load()
+	if err != nil {
+		return "", err
+	}
+	return m.version.value, nil
+}
+
+// Write sets the version in Cargo.toml and Cargo.lock. Both are edited in
+// memory before either is written.
+func (c *Cargo) Write(version string) error {
+	m, err := c.load()
+	if err != nil {
+		return err
+	}
+	old := m.version.value
+	setValue(m.lines, m.version, version)
+	manifest := []byte(strings.Join(m.lines, ""))
+
+	lockPath, err := c.lockPath()
+	if err != nil {
+		return err
+	}
+	var lock []byte
+	if lockPath != "" {
+		if lock, err = os.ReadFile(lockPath); err != nil {
+			return err
+		}
+		lock = updateCargoLock(lock, m.name, old, version)
+	}
+
+	if err := writeAtomic(c.Path, manifest); err != nil {
+		return err
+	}
+	if lock != nil {
+		return writeAtomic(lockPath, lock)
+	}
+	return nil
+}
+
+func (c *Cargo) Describe() string {
+	return c.Path
+}
+
+// updateCargoLock rewrites the version of the local [[package]] entries
+// (those without a source) at version old, restricted to the crate called
+// name unless name is "".
+func updateCargoLock(data []byte, name, old, version string) []byte {
+	lines := strings.SplitAfter(string(data), "\n")
+
+	var (
+		inPackage bool
+		entryName string
+		entryVer  *lineValue
+		hasSource bool
+	)
+	flush := func() {
+		if inPackage && entryVer != nil && !hasSource && entryVer.value == old && (name == "" || entryName == name) {
+			setValue(lines, *entryVer, version)
+		}
+		inPackage, entryName, entryVer, hasSource = false, "", nil, false
+	}
+	for i, line := range lines {
+		if t := tomlArray.FindStringSubmatch(line); t != nil {
+			flush()
+			inPackage = strings.TrimSpace(t[1]) == "package"
+			continue
+		}
+		if tomlTable.MatchString(line) {
+			flush()
+			continue
+		}
+		if !inPackage {
+			continue
+		}
+		if v, ok := matchValue(tomlName, lines, i); ok {
+			entryName = v.value
+		} else if v, ok := matchValue(tomlVersion, lines, i); ok {
+			entryVer = &v
+		} else if tomlSource.MatchString(line) {
+			hasSource = true
+		}
+	}
+	flush()
+	return []byte(strings.Join(lines, ""))
+}
@@ -0,0 +1,186 @@
+package store
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var _ VersionStore = (*Cargo)(nil)
+
+const cargoToml = `# Service crate
+[package]
+name    = "svc"
+version = "1.2.3"   # bumped by semver
+edition = "2021"
+
+[dependencies]
+serde = { version = "1.0", features = ["derive"] }
+
+[dev-dependencies.tokio]
+version = "1.2.3"
+`
+
+const cargoLock = `# This file is automatically @generated by Cargo.
+version = 3
+
+[[package]]
+name = "svc"
+version = "1.2.3"
+dependencies = [
+ "serde",
+]
+
+[[package]]
+name = "tokio"
+version = "1.2.3"
+source = "registry+https://github.com/rust-lang/crates.io-index"
+`
+
+// repoDir returns a temporary directory marked as a git root, so the search
+// for Cargo.lock stops there.
+func repoDir(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(dir, ".git"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestCargo_WritePreservesFormattingAndSyncsLock(t *testing.T) {
+	dir := repoDir(t)
+	writeFiles(t, dir, map[string]string{"Cargo.toml": cargoToml, "Cargo.lock": cargoLock})
+	c := NewCargo(filepath.Join(dir, "Cargo.toml"))
+
+	if got, err := c.Read(); err != nil || got != "1.2.3" {
+		t.Fatalf("Read() = %q, %v", got, err)
+	}
+	if err := c.Write("1.3.0-rc.1"); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	want := `# Service crate
+[package]
+name    = "svc"
+version = "1.3.0-rc.1"   # bumped by semver
+edition = "2021"
+
+[dependencies]
+serde = { version = "1.0", features = ["derive"] }
+
+[dev-dependencies.tokio]
+version = "1.2.3"
+`
+	if got := readFile(t, c.Path); got != want {
+		t.Fatalf("Cargo.toml:\n%s\nwant:\n%s", got, want)
+	}
+	wantLock := `# This file is automatically @generated by Cargo.
+version = 3
+
+[[package]]
+name = "svc"
+version = "1.3.0-rc.1"
+dependencies = [
+ "serde",
+]
+
+[[package]]
+name = "tokio"
+version = "1.2.3"
+source = "registry+https://github.com/rust-lang/crates.io-index"
+`
+	if got := readFile(t, filepath.Join(dir, "Cargo.lock")); got != wantLock {
+		t.Fatalf("Cargo.lock:\n%s\nwant:\n%s", got, wantLock)
+	}
+}
+
+func TestCargo_Workspace(t *testing.T) {
+	dir := repoDir(t)
+	writeFiles(t, dir, map[string]string{
+		"Cargo.toml": "[workspace]\nmembers = [\"api\", \"cli\"]\n\n[workspace.package]\nversion = '0.9.0'\n",
+		"Cargo.lock": "[[package]]\nname = \"api\"\nversion = \"0.9.0\"\n\n[[package]]\nname = \"cli\"\nversion = \"0.9.0\"\n\n[[package]]\nname = \"legacy\"\nversion = \"0.1.0\"\n",
+	})
+	c := NewCargo(filepath.Join(dir, "Cargo.toml"))
+	if got, err := c.Read(); err != nil || got != "0.9.0" {
+		t.Fatalf("Read() = %q, %v", got, err)
+	}
+	if err := c.Write("1.0.0"); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if got := readFile(t, c.Path); got != "[workspace]\nmembers = [\"api\", \"cli\"]\n\n[workspace.package]\nversion = '1.0.0'\n" {
+		t.Fatalf("Cargo.toml:\n%s", got)
+	}
+	wantLock := "[[package]]\nname = \"api\"\nversion = \"1.0.0\"\n\n[[package]]\nname = \"cli\"\nversion = \"1.0.0\"\n\n[[package]]\nname = \"legacy\"\nversion = \"0.1.0\"\n"
+	if got := readFile(t, filepath.Join(dir, "Cargo.lock")); got != wantLock {
+		t.Fatalf("Cargo.lock:\n%s", got)
+	}
+}
+
+func TestCargo_PackageInheritsWorkspaceVersion(t *testing.T) {
+	dir := repoDir(t)
+	writeFiles(t, dir, map[string]string{
+		"Cargo.toml": "[package]\nname = \"app\"\nversion.workspace = true\n\n[workspace.package]\nversion = \"2.0.0\"\n",
+	})
+	c := NewCargo(filepath.Join(dir, "Cargo.toml"))
+	if err := c.Write("2.1.0"); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if got := readFile(t, c.Path); got != "[package]\nname = \"app\"\nversion.workspace = true\n\n[workspace.package]\nversion = \"2.1.0\"\n" {
+		t.Fatalf("Cargo.toml:\n%s", got)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "Cargo.lock")); !os.IsNotExist(err) {
+		t.Fatalf("Cargo.lock should not be created")
+	}
+}
+
+func TestCargo_Errors(t *testing.T) {
+	dir := repoDir(t)
+	c := NewCargo(filepath.Join(dir, "Cargo.toml"))
+	if _, err := c.Read(); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("missing Cargo.toml: expected ErrNotFound, got %v", err)
+	}
+
+	writeFiles(t, dir, map[string]string{"Cargo.toml": "[dependencies]\nversion = \"1.0.0\"\n"})
+	if _, err := c.Read(); !errors.Is(err, ErrNoVersionField) {
+		t.Fatalf("no package version: expected ErrNoVersionField, got %v", err)
+	}
+	if err := c.Write("1.0.0"); err == nil {
+		t.Fatalf("Write without a package version should fail")
+	}
+}
+
+func TestCargo_CommentedHeader(t *testing.T) {
+	dir := repoDir(t)
+	writeFiles(t, dir, map[string]string{"Cargo.toml": "[package] # main crate\r\nname = \"svc\"\r\nversion = \"1.0.0\"\r\n"})
+	c := NewCargo(filepath.Join(dir, "Cargo.toml"))
+	if got, err := c.Read(); err != nil || got != "1.0.0" {
+		t.Fatalf("Read() = %q, %v", got, err)
+	}
+}
+
+func TestCargo_WorkspaceMemberUpdatesRootLock(t *testing.T) {
+	dir := repoDir(t)
+	if err := os.Mkdir(filepath.Join(dir, "api"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	writeFiles(t, dir, map[string]string{
+		"Cargo.toml":     "[workspace]\nmembers = [\"api\"]\n",
+		"Cargo.lock":     "[[package]]\nname = \"api\"\nversion = \"1.0.0\"\n",
+		"api/Cargo.toml": "[package]\nname = \"api\"\nversion = \"1.0.0\"\n",
+	})
+	c := NewCargo(filepath.Join(dir, "api", "Cargo.toml"))
+	if err := c.Write("1.0.1"); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if got := readFile(t, c.Path); got != "[package]\nname = \"api\"\nversion = \"1.0.1\"\n" {
+		t.Fatalf("api/Cargo.toml:\n%s", got)
+	}
+	if got := readFile(t, filepath.Join(dir, "Cargo.lock")); got != "[[package]]\nname = \"api\"\nversion = \"1.0.1\"\n" {
+		t.Fatalf("Cargo.lock:\n%s", got)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "api", "Cargo.lock")); !os.IsNotExist(err) {
+		t.Fatalf("api/Cargo.lock should not be created")
+	}
+}
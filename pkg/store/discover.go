@@ -0,0 +1,41 @@
+package store
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// FileName is the name of the default version file.
+const FileName = "VERSION"
+
+// Discover looks for FileName in dir and then in each parent, stopping after
+// the first directory that contains .git (the repository root) or at the
+// filesystem root. It returns the path of the file found, or "" if there is
+// none.
+func Discover(dir string) (string, error) {
+	return Find(dir, FileName)
+}
+
+// Find is like Discover but looks for the file called name.
+func Find(dir, name string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	for {
+		path := filepath.Join(dir, name)
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		} else if !os.IsNotExist(err) {
+			return "", err
+		}
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return "", nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", nil
+		}
+		dir = parent
+	}
+}
@@ -0,0 +1,42 @@
+package store
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDiscover(t *testing.T) {
+	root := t.TempDir()
+	repo := filepath.Join(root, "repo")
+	sub := filepath.Join(repo, "a", "b")
+	for _, dir := range []string{sub, filepath.Join(repo, ".git")} {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// outside the repository, so it must not be found
+	if err := os.WriteFile(filepath.Join(root, FileName), []byte("9.9.9\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, err := Discover(sub); err != nil || got != "" {
+		t.Fatalf("Discover stopped past the git root: %q, %v", got, err)
+	}
+
+	want := filepath.Join(repo, FileName)
+	if err := os.WriteFile(want, []byte("1.2.3\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := Discover(sub); err != nil || got != want {
+		t.Fatalf("Discover(%s) = %q, %v; want %q", sub, got, err, want)
+	}
+
+	nearer := filepath.Join(repo, "a", FileName)
+	if err := os.WriteFile(nearer, []byte("0.1.0\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := Discover(sub); got != nearer {
+		t.Fatalf("Discover should prefer the nearest file, got %q", got)
+	}
+}
@@ -0,0 +1,38 @@
+package store
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// File stores the version as a single line in a plain text file, by default
+// VERSION in the working directory.
+type File struct {
+	Path string
+}
+
+// NewFile returns a store for the file at path.
+func NewFile(path string) *File {
+	return &File{Path: path}
+}
+
+func (f *File) Read() (string, error) {
+	b, err := os.ReadFile(f.Path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", fmt.Errorf("%s: %w", f.Path, ErrNotFound)
+		}
+		return "", err
+	}
+	return strings.TrimSpace(string(b)), nil
+}
+
+// Write atomically replaces the file with version and a trailing newline.
+func (f *File) Write(version string) error {
+	return writeAtomic(f.Path, []byte(version+"\n"))
+}
+
+func (f *File) Describe() string {
+	return "file " + f.Path
+}
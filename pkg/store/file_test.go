@@ -0,0 +1,40 @@
+package store
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var _ VersionStore = (*File)(nil)
+
+func TestFile_ReadWrite(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "VERSION")
+	f := NewFile(path)
+
+	if _, err := f.Read(); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for a missing file, got %v", err)
+	}
+	if err := f.Write("1.2.3"); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	b, err := os.ReadFile(path)
+	if err != nil || string(b) != "1.2.3\n" {
+		t.Fatalf("file holds %q (%v)", b, err)
+	}
+	if err := os.WriteFile(path, []byte("  1.2.3.4\r\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := f.Read(); err != nil || got != "1.2.3.4" {
+		t.Fatalf("Read() = %q, %v", got, err)
+	}
+
+	entries, _ := os.ReadDir(filepath.Dir(path))
+	if len(entries) != 1 {
+		t.Fatalf("temporary file left behind: %v", entries)
+	}
+	if got := f.Describe(); got != "file "+path {
+		t.Fatalf("Describe() = %q", got)
+	}
+}
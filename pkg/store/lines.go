@@ -0,0 +1,67 @@
+package store
+
+import (
+	"regexp"
+	"strings"
+)
+
+// The file-based stores edit manifests line by line rather than decoding and
+// re-encoding them, so that only the version changes.
+
+var (
+	// lines keep their "\n", which "." does not match
+	tomlTable   = regexp.MustCompile(`^\s*\[\s*([^\[\]]+?)\s*\]\s*(?:#[^\n]*)?\s*$`)
+	tomlArray   = regexp.MustCompile(`^\s*\[\[\s*([^\[\]]+?)\s*\]\]\s*(?:#[^\n]*)?\s*$`)
+	tomlVersion = regexp.MustCompile(`^\s*version\s*=\s*(?:"([^"\\]*)"|'([^']*)')`)
+	tomlName    = regexp.MustCompile(`^\s*name\s*=\s*(?:"([^"\\]*)"|'([^']*)')`)
+	tomlSource  = regexp.MustCompile(`^\s*source\s*=`)
+)
+
+// lineValue is a value found on one line of a TOML, INI or Python file.
+type lineValue struct {
+	line       int // index into the lines
+	start, end int // byte span of the value within the line, quotes excluded
+	value      string
+}
+
+// matchValue returns the value the regexp re captures on line i, in its
+// first or, failing that, second group.
+func matchValue(re *regexp.Regexp, lines []string, i int) (lineValue, bool) {
+	m := re.FindStringSubmatchIndex(lines[i])
+	if m == nil {
+		return lineValue{}, false
+	}
+	start, end := m[2], m[3]
+	if start < 0 {
+		start, end = m[4], m[5]
+	}
+	return lineValue{line: i, start: start, end: end, value: lines[i][start:end]}, true
+}
+
+// setValue replaces v on its line with value.
+func setValue(lines []string, v lineValue, value string) {
+	line := lines[v.line]
+	lines[v.line] = line[:v.start] + value + line[v.end:]
+}
+
+// findInSection returns the first value re matches within the [section]
+// table of a TOML or INI file.
+func findInSection(lines []string, section string, re *regexp.Regexp) (lineValue, bool) {
+	in := false
+	for i, line := range lines {
+		if t := tomlTable.FindStringSubmatch(line); t != nil {
+			in = strings.Join(strings.Fields(t[1]), "") == section
+			continue
+		}
+		if tomlArray.MatchString(line) {
+			in = false
+			continue
+		}
+		if in {
+			if v, ok := matchValue(re, lines, i); ok {
+				return v, true
+			}
+		}
+	}
+	return lineValue{}, false
+}
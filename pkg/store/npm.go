@@ -0,0 +1,174 @@
+package store
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// NPM stores the version in the "version" field of a package.json. Writes
+// touch nothing but that value, so key order, indentation and the rest of
+// the document are preserved, and update package-lock.json next to it (the
+// top-level "version" and packages[""].version, where present) when there is
+// one.
+type NPM struct {
+	Path string // path of package.json
+}
+
+// NewNPM returns a store for the package.json at path.
+func NewNPM(path string) *NPM {
+	return &NPM{Path: path}
+}
+
+func (n *NPM) lockPath() string {
+	return filepath.Join(filepath.Dir(n.Path), "package-lock.json")
+}
+
+func (n *NPM) Read() (string, error) {
+	data, err := os.ReadFile(n.Path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", fmt.Errorf("%s: %w", n.Path, ErrNotFound)
+		}
+		return "", err
+	}
+	var pkg struct {
+		Version *string `json:"version"`
+	}
+	if err := json.Unmarshal(data, &pkg); err != nil {
+		return "", fmt.Errorf("%s: %w", n.Path, err)
+	}
+	if pkg.Version == nil {
+		return "", fmt.Errorf("%s has %w", n.Path, ErrNoVersionField)
+	}
+	return *pkg.Version, nil
+}
+
+// Write sets the version in package.json and package-lock.json. Both
+// documents are edited in memory before either is written, so a malformed
+// lockfile leaves package.json untouched too.
+func (n *NPM) Write(version string) error {
+	data, err := os.ReadFile(n.Path)
+	if err != nil {
+		return err
+	}
+	pkg, err := replaceJSONString(data, version, "version")
+	if errors.Is(err, errNoField) {
+		return fmt.Errorf("%s has %w", n.Path, ErrNoVersionField)
+	} else if err != nil {
+		return fmt.Errorf("%s: %w", n.Path, err)
+	}
+
+	lock, err := os.ReadFile(n.lockPath())
+	switch {
+	case os.IsNotExist(err):
+		lock = nil
+	case err != nil:
+		return err
+	default:
+		// lockfileVersion 1 has no packages map, and some lockfiles carry
+		// the version only in packages[""]
+		for _, path := range [][]string{{"version"}, {"packages", "", "version"}} {
+			next, err := replaceJSONString(lock, version, path...)
+			switch {
+			case err == nil:
+				lock = next
+			case !errors.Is(err, errNoField):
+				return fmt.Errorf("%s: %w", n.lockPath(), err)
+			}
+		}
+	}
+
+	if err := writeAtomic(n.Path, pkg); err != nil {
+		return err
+	}
+	if lock != nil {
+		return writeAtomic(n.lockPath(), lock)
+	}
+	return nil
+}
+
+func (n *NPM) Describe() string {
+	return n.Path
+}
+
+// errNoField is returned by replaceJSONString when the path does not exist.
+var errNoField = errors.New("no such field")
+
+// replaceJSONString returns a copy of the JSON document data with the string
+// at path (a sequence of object keys) replaced by value. Every other byte is
+// kept as is.
+func replaceJSONString(data []byte, value string, path ...string) ([]byte, error) {
+	start, end, err := findJSONString(data, path)
+	if err != nil {
+		return nil, err
+	}
+	quoted, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]byte, 0, len(data)-(end-start)+len(quoted))
+	out = append(out, data[:start]...)
+	out = append(out, quoted...)
+	return append(out, data[end:]...), nil
+}
+
+// findJSONString returns the byte span, quotes included, of the string at
+// path in data.
+func findJSONString(data []byte, path []string) (int, int, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if tok, err := dec.Token(); err != nil {
+		return 0, 0, err
+	} else if tok != json.Delim('{') {
+		return 0, 0, errors.New("not a JSON object")
+	}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return 0, 0, err
+		}
+		if tok != path[0] {
+			var skip json.RawMessage
+			if err := dec.Decode(&skip); err != nil {
+				return 0, 0, err
+			}
+			continue
+		}
+
+		off := dec.InputOffset()
+		tok, err = dec.Token()
+		if err != nil {
+			return 0, 0, err
+		}
+		if len(path) > 1 {
+			if tok != json.Delim('{') {
+				return 0, 0, fmt.Errorf("%q is not an object", path[0])
+			}
+			path = path[1:]
+			continue
+		}
+		if _, ok := tok.(string); !ok {
+			return 0, 0, fmt.Errorf("%q is not a string", path[0])
+		}
+		end := int(dec.InputOffset())
+		return int(off) + bytes.IndexByte(data[off:end], '"'), end, nil
+	}
+	return 0, 0, errNoField
+}
+
+// writeAtomic replaces path with data via a temporary file in the same
+// directory, keeping the file's permissions.
+func writeAtomic(path string, data []byte) error {
+	mode := os.FileMode(0o644)
+	if fi, err := os.Stat(path); err == nil {
+		mode = fi.Mode().Perm()
+	}
+	tmp := filepath.Join(filepath.Dir(path), "."+filepath.Base(path)+".tmp")
+	if err := os.WriteFile(tmp, data, mode); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
@@ -0,0 +1,159 @@
+package store
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var _ VersionStore = (*NPM)(nil)
+
+const packageJSON = `{
+	"name": "web",
+	"version": "1.2.3",
+	"private": true,
+	"scripts": { "build": "vite build" },
+	"dependencies": {
+		"left-pad": "^1.3.0"
+	}
+}
+`
+
+const packageLock = `{
+  "name": "web",
+  "version": "1.2.3",
+  "lockfileVersion": 3,
+  "requires": true,
+  "packages": {
+    "node_modules/left-pad": {
+      "version": "1.3.0"
+    },
+    "": {
+      "name": "web",
+      "version": "1.2.3"
+    }
+  }
+}
+`
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
+
+func TestNPM_WritePreservesFormattingAndSyncsLock(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{"package.json": packageJSON, "package-lock.json": packageLock})
+	n := NewNPM(filepath.Join(dir, "package.json"))
+
+	if got, err := n.Read(); err != nil || got != "1.2.3" {
+		t.Fatalf("Read() = %q, %v", got, err)
+	}
+	if err := n.Write("1.3.0-rc.1"); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	want := `{
+	"name": "web",
+	"version": "1.3.0-rc.1",
+	"private": true,
+	"scripts": { "build": "vite build" },
+	"dependencies": {
+		"left-pad": "^1.3.0"
+	}
+}
+`
+	if got := readFile(t, n.Path); got != want {
+		t.Fatalf("package.json:\n%s\nwant:\n%s", got, want)
+	}
+	wantLock := `{
+  "name": "web",
+  "version": "1.3.0-rc.1",
+  "lockfileVersion": 3,
+  "requires": true,
+  "packages": {
+    "node_modules/left-pad": {
+      "version": "1.3.0"
+    },
+    "": {
+      "name": "web",
+      "version": "1.3.0-rc.1"
+    }
+  }
+}
+`
+	if got := readFile(t, filepath.Join(dir, "package-lock.json")); got != wantLock {
+		t.Fatalf("package-lock.json:\n%s\nwant:\n%s", got, wantLock)
+	}
+}
+
+func TestNPM_WithoutLockfile(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{"package.json": `{"name":"a","version":"0.1.0"}`})
+	n := NewNPM(filepath.Join(dir, "package.json"))
+	if err := n.Write("0.2.0"); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if got := readFile(t, n.Path); got != `{"name":"a","version":"0.2.0"}` {
+		t.Fatalf("package.json = %s", got)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "package-lock.json")); !os.IsNotExist(err) {
+		t.Fatalf("package-lock.json should not be created")
+	}
+}
+
+func TestNPM_Errors(t *testing.T) {
+	dir := t.TempDir()
+	n := NewNPM(filepath.Join(dir, "package.json"))
+	if _, err := n.Read(); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("missing package.json: expected ErrNotFound, got %v", err)
+	}
+
+	writeFiles(t, dir, map[string]string{"package.json": `{"name":"a"}`})
+	if _, err := n.Read(); !errors.Is(err, ErrNoVersionField) {
+		t.Fatalf("missing version: expected ErrNoVersionField, got %v", err)
+	}
+	if err := n.Write("1.0.0"); err == nil {
+		t.Fatalf("Write without a version field should fail")
+	}
+
+	writeFiles(t, dir, map[string]string{
+		"package.json":      `{"version":"1.0.0"}`,
+		"package-lock.json": `{"version": 1}`,
+	})
+	if err := n.Write("2.0.0"); err == nil {
+		t.Fatalf("Write with a malformed lockfile should fail")
+	}
+	if got := readFile(t, n.Path); got != `{"version":"1.0.0"}` {
+		t.Fatalf("package.json changed despite the lockfile error: %s", got)
+	}
+}
+
+func TestNPM_LockWithoutTopLevelVersion(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"package.json":      `{"version":"1.0.0"}`,
+		"package-lock.json": `{"lockfileVersion":3,"packages":{"":{"version":"1.0.0"}}}`,
+	})
+	n := NewNPM(filepath.Join(dir, "package.json"))
+	if err := n.Write("1.1.0"); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if got := readFile(t, filepath.Join(dir, "package-lock.json")); got != `{"lockfileVersion":3,"packages":{"":{"version":"1.1.0"}}}` {
+		t.Fatalf("package-lock.json = %s", got)
+	}
+}
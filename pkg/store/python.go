@@ -0,0 +1,118 @@
+package store
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/dp1140a/semver/pkg/types"
+)
+
+// Python stores the version of a Python project. Where it looks depends on
+// the file: [project].version in pyproject.toml, version in the [metadata]
+// section of setup.cfg, and the __version__ assignment in any other file,
+// taken to be a module such as mypkg/__init__.py. Writes replace only the
+// value, and versions are kept in PEP 440 form: the store writes 1.2.0-rc.1
+// as 1.2.0rc1 and reads it back as 1.2.0-rc.1. Only versions that read back
+// unchanged can be written; see types.Version.PEP440.
+type Python struct {
+	Path string // pyproject.toml, setup.cfg or a .py module
+}
+
+// NewPython returns a store for the Python project file at path.
+func NewPython(path string) *Python {
+	return &Python{Path: path}
+}
+
+var (
+	iniVersion    = regexp.MustCompile(`^\s*version\s*[=:]\s*(\S(?:.*\S)?)`)
+	moduleVersion = regexp.MustCompile(`^__version__\s*(?::\s*str\s*)?=\s*(?:"([^"\\]*)"|'([^']*)')`)
+)
+
+// locate returns the lines of the file and where the version is in them.
+func (p *Python) locate() ([]string, lineValue, error) {
+	data, err := os.ReadFile(p.Path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, lineValue{}, fmt.Errorf("%s: %w", p.Path, ErrNotFound)
+		}
+		return nil, lineValue{}, err
+	}
+	lines := strings.SplitAfter(string(data), "\n")
+
+	var (
+		v     lineValue
+		ok    bool
+		where string
+	)
+	switch filepath.Base(p.Path) {
+	case "pyproject.toml":
+		v, ok = findInSection(lines, "project", tomlVersion)
+		where = "in [project]"
+	case "setup.cfg":
+		v, ok = findInSection(lines, "metadata", iniVersion)
+		where = "in [metadata]"
+		if ok && (strings.HasPrefix(v.value, "attr:") || strings.HasPrefix(v.value, "file:")) {
+			return nil, lineValue{}, fmt.Errorf("%s takes its version from %q; use that file instead", p.Path, v.value)
+		}
+	default:
+		for i := range lines {
+			if v, ok = matchValue(moduleVersion, lines, i); ok {
+				break
+			}
+		}
+		where = "(__version__)"
+	}
+	if !ok {
+		return nil, lineValue{}, fmt.Errorf("%s has %w %s", p.Path, ErrNoVersionField, where)
+	}
+	return lines, v, nil
+}
+
+// Read returns the version converted from PEP 440 to SemVer.
+func (p *Python) Read() (string, error) {
+	_, v, err := p.locate()
+	if err != nil {
+		return "", err
+	}
+	parsed, err := types.ParsePEP440(v.value)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", p.Path, err)
+	}
+	return parsed.String(), nil
+}
+
+// Write stores version in PEP 440 form. Versions with no PEP 440 equivalent,
+// such as 1.0.0-nightly, are rejected without touching the file.
+func (p *Python) Write(version string) error {
+	pep, err := pep440(version)
+	if err != nil {
+		return err
+	}
+	lines, v, err := p.locate()
+	if err != nil {
+		return err
+	}
+	setValue(lines, v, pep)
+	return writeAtomic(p.Path, []byte(strings.Join(lines, "")))
+}
+
+// Check reports whether Write could store version.
+func (p *Python) Check(version string) error {
+	_, err := pep440(version)
+	return err
+}
+
+func (p *Python) Describe() string {
+	return p.Path
+}
+
+func pep440(version string) (string, error) {
+	v, err := types.Parse(version)
+	if err != nil {
+		return "", err
+	}
+	return v.PEP440()
+}
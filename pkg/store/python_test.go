@@ -0,0 +1,87 @@
+package store
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/dp1140a/semver/pkg/types"
+)
+
+var (
+	_ VersionStore = (*Python)(nil)
+	_ Checker      = (*Python)(nil)
+)
+
+func TestPython_Locations(t *testing.T) {
+	tests := []struct {
+		name, before, after string
+	}{
+		{
+			"pyproject.toml",
+			"[build-system]\nrequires = [\"hatchling\"]\n\n[project] # metadata\nname = \"tool\"\nversion = \"1.2.0rc1\"  # PEP 440\n\n[tool.ruff]\nversion = \"0.1\"\n",
+			"[build-system]\nrequires = [\"hatchling\"]\n\n[project] # metadata\nname = \"tool\"\nversion = \"1.3.0a2\"  # PEP 440\n\n[tool.ruff]\nversion = \"0.1\"\n",
+		},
+		{
+			"setup.cfg",
+			"[options]\nversion = 9\n\n[metadata]\nname = tool\nversion : 1.2.0rc1\n",
+			"[options]\nversion = 9\n\n[metadata]\nname = tool\nversion : 1.3.0a2\n",
+		},
+		{
+			"__init__.py",
+			"\"\"\"Tool.\"\"\"\n\n__version__: str = '1.2.0rc1'\n__all__ = [\"run\"]\n",
+			"\"\"\"Tool.\"\"\"\n\n__version__: str = '1.3.0a2'\n__all__ = [\"run\"]\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeFiles(t, dir, map[string]string{tt.name: tt.before})
+			p := NewPython(filepath.Join(dir, tt.name))
+
+			if got, err := p.Read(); err != nil || got != "1.2.0-rc.1" {
+				t.Fatalf("Read() = %q, %v", got, err)
+			}
+			if err := p.Write("1.3.0-alpha.2"); err != nil {
+				t.Fatalf("Write: %v", err)
+			}
+			if got := readFile(t, p.Path); got != tt.after {
+				t.Fatalf("%s:\n%s\nwant:\n%s", tt.name, got, tt.after)
+			}
+			if got, err := p.Read(); err != nil || got != "1.3.0-alpha.2" {
+				t.Fatalf("Read() after Write = %q, %v", got, err)
+			}
+		})
+	}
+}
+
+func TestPython_Errors(t *testing.T) {
+	dir := t.TempDir()
+	p := NewPython(filepath.Join(dir, "pyproject.toml"))
+	if _, err := p.Read(); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("missing pyproject.toml: expected ErrNotFound, got %v", err)
+	}
+
+	writeFiles(t, dir, map[string]string{"pyproject.toml": "[project]\nname = \"tool\"\ndynamic = [\"version\"]\n"})
+	if _, err := p.Read(); !errors.Is(err, ErrNoVersionField) {
+		t.Fatalf("dynamic version: expected ErrNoVersionField, got %v", err)
+	}
+
+	writeFiles(t, dir, map[string]string{"pyproject.toml": "[project]\nversion = \"1.0.0\"\n"})
+	for _, v := range []string{"1.1.0-nightly.1", "1.1.0-alpha", "1.1.0-a.1"} {
+		if err := p.Check(v); !errors.Is(err, types.ErrNotPEP440) {
+			t.Fatalf("Check(%s): expected ErrNotPEP440, got %v", v, err)
+		}
+		if err := p.Write(v); !errors.Is(err, types.ErrNotPEP440) {
+			t.Fatalf("Write(%s): expected ErrNotPEP440, got %v", v, err)
+		}
+	}
+	if got := readFile(t, p.Path); got != "[project]\nversion = \"1.0.0\"\n" {
+		t.Fatalf("pyproject.toml changed despite the error:\n%s", got)
+	}
+
+	writeFiles(t, dir, map[string]string{"setup.cfg": "[metadata]\nversion = attr: tool.__version__\n"})
+	if _, err := NewPython(filepath.Join(dir, "setup.cfg")).Read(); err == nil || errors.Is(err, ErrNotFound) {
+		t.Fatalf("attr: version should be reported, got %v", err)
+	}
+}
@@ -0,0 +1,35 @@
+// Package store abstracts where a project keeps its version: a VERSION file
+// today, package manifests or tags tomorrow.
+package store
+
+import "errors"
+
+// ErrNotFound is returned by Read when the store holds no version yet, for
+// example because the VERSION file does not exist.
+var ErrNotFound = errors.New("no version found")
+
+// ErrNoVersionField is returned by the manifest stores when the manifest
+// exists but declares no version, like a pyproject.toml with
+// dynamic = ["version"]. Writing does not add the field, so unlike
+// ErrNotFound this is not fixed by running init.
+var ErrNoVersionField = errors.New("no version field")
+
+// VersionStore reads and writes a project's version string. Implementations
+// do not validate the version; that is up to the caller.
+type VersionStore interface {
+	// Read returns the current version with surrounding whitespace removed,
+	// or an error wrapping ErrNotFound if there is none.
+	Read() (string, error)
+	// Write replaces the current version.
+	Write(version string) error
+	// Describe names the store for messages, e.g. "file VERSION".
+	Describe() string
+}
+
+// Checker is implemented by stores that cannot hold every version, such as
+// Python's, which needs a PEP 440 equivalent.
+type Checker interface {
+	// Check returns the error Write would fail with for version, without
+	// writing anything.
+	Check(version string) error
+}
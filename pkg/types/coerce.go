@@ -0,0 +1,176 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/dp1140a/semver/pkg/util"
+)
+
+// ErrNoVersion is returned by Coerce when the input contains no number.
+var ErrNoVersion = errors.New("no version number found")
+
+// Coerce extracts the best SemVer match from a real-world version string
+// such as "1.2", "v1", "1.2.3.4", "release-1.2.3" or "1.2.3_beta". It takes
+// the first run of dot-separated numbers, keeps up to three of them and
+// fills in missing ones with 0, then keeps a prerelease and build that
+// follow, repairing what it can. The returned notes describe everything that
+// was changed or dropped; they are empty when the input was already valid.
+func Coerce(version string) (Version, []string, error) {
+	lead := len(version) - len(strings.TrimLeft(version, " \t\r\n"))
+	s := strings.TrimSpace(version)
+	var notes []string
+
+	i := strings.IndexFunc(s, func(r rune) bool { return r >= '0' && r <= '9' })
+	if i < 0 {
+		return Version{}, nil, &ParseError{Input: version, Offset: lead, Err: ErrNoVersion}
+	}
+	if prefix := s[:i]; prefix != "" && prefix != "v" && prefix != "V" {
+		notes = append(notes, fmt.Sprintf("dropped prefix %q", prefix))
+	}
+
+	// dot-separated numbers: 1, 1.2, 1.2.3, 1.2.3.4, ...
+	var parts []string
+	var offsets []int
+	for {
+		j := i
+		for j < len(s) && util.IsDigit(s[j]) {
+			j++
+		}
+		parts = append(parts, s[i:j])
+		offsets = append(offsets, i)
+		i = j
+		if i+1 < len(s) && s[i] == '.' && util.IsDigit(s[i+1]) {
+			i++
+			continue
+		}
+		break
+	}
+	switch {
+	case len(parts) > 3:
+		notes = append(notes, fmt.Sprintf("dropped extra segment(s) .%s", strings.Join(parts[3:], ".")))
+		parts = parts[:3]
+	case len(parts) == 2:
+		notes = append(notes, "filled in missing patch with 0")
+	case len(parts) == 1:
+		notes = append(notes, "filled in missing minor and patch with 0")
+	}
+
+	var v Version
+	for n, dst := range []*uint64{&v.Major, &v.Minor, &v.Patch}[:len(parts)] {
+		digits, trimmed := parts[n], trimZeros(parts[n])
+		if trimmed != digits {
+			notes = append(notes, fmt.Sprintf("removed leading zeros from %q", digits))
+		}
+		num, err := parseInt(trimmed)
+		if err != nil {
+			return Version{}, nil, &ParseError{Input: version, Offset: lead + offsets[n], Err: err}
+		}
+		*dst = num
+	}
+
+	// prerelease: "-beta", "_beta" or a directly attached "beta"
+	if i < len(s) && (s[i] == '-' || s[i] == '_' || isLetter(s[i])) {
+		j := i
+		if !isLetter(s[i]) {
+			j++
+		}
+		k := scanLoose(s, j)
+		if k > j {
+			switch {
+			case s[i] == '_':
+				notes = append(notes, "replaced '_' before the prerelease with '-'")
+			case isLetter(s[i]):
+				notes = append(notes, "inserted '-' before the prerelease")
+			}
+			var preNotes []string
+			v.PreRelease, preNotes = coercePre(s[j:k])
+			notes = append(notes, preNotes...)
+			i = k
+		}
+	}
+
+	// build metadata
+	if i < len(s) && s[i] == '+' {
+		j := scanLoose(s, i+1)
+		var buildNotes []string
+		v.Build, buildNotes = coerceBuild(s[i+1 : j])
+		notes = append(notes, buildNotes...)
+		i = j
+	}
+
+	if i < len(s) {
+		notes = append(notes, fmt.Sprintf("dropped trailing %q", s[i:]))
+	}
+	return v, notes, nil
+}
+
+func isLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// scanLoose returns the end of the run of identifier characters, dots and
+// underscores starting at i.
+func scanLoose(s string, i int) int {
+	for i < len(s) && (util.IsIdentChar(s[i]) || s[i] == '_' || s[i] == '.') {
+		i++
+	}
+	return i
+}
+
+func trimZeros(digits string) string {
+	trimmed := strings.TrimLeft(digits, "0")
+	if trimmed == "" {
+		return "0"
+	}
+	return trimmed
+}
+
+// splitLoose replaces '_' with '-' and splits on '.', dropping empty
+// identifiers.
+func splitLoose(s string) ([]string, []string) {
+	var notes []string
+	if strings.Contains(s, "_") {
+		notes = append(notes, fmt.Sprintf("replaced '_' with '-' in %q", s))
+		s = strings.ReplaceAll(s, "_", "-")
+	}
+	var ids []string
+	for _, part := range strings.Split(s, ".") {
+		if part != "" {
+			ids = append(ids, part)
+		}
+	}
+	if len(ids) != strings.Count(s, ".")+1 {
+		notes = append(notes, fmt.Sprintf("dropped empty identifiers from %q", s))
+	}
+	return ids, notes
+}
+
+func coercePre(s string) (string, []string) {
+	parts, notes := splitLoose(s)
+	var pre PreRelease
+	for _, part := range parts {
+		if isNumeric(part) {
+			if trimmed := trimZeros(part); trimmed != part {
+				notes = append(notes, fmt.Sprintf("removed leading zeros from %q", part))
+				part = trimmed
+			}
+		}
+		id, _, err := parseIdentifier(part)
+		if err != nil {
+			notes = append(notes, fmt.Sprintf("dropped prerelease identifier %q: %v", part, err))
+			continue
+		}
+		pre = append(pre, id)
+	}
+	return pre.String(), notes
+}
+
+func coerceBuild(s string) (string, []string) {
+	parts, notes := splitLoose(s)
+	if len(parts) == 0 {
+		notes = append(notes, "dropped empty build metadata")
+	}
+	return strings.Join(parts, "."), notes
+}
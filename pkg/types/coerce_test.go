@@ -0,0 +1,52 @@
+package types
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCoerce(t *testing.T) {
+	tests := []struct {
+		in    string
+		want  string
+		notes int
+	}{
+		{"1.2.3", "1.2.3", 0},
+		{"v1.2.3-rc.1+b.7", "1.2.3-rc.1+b.7", 0},
+		{"1.2", "1.2.0", 1},
+		{"v1", "1.0.0", 1},
+		{"1.2.3.4", "1.2.3", 1},
+		{"release-1.2.3", "1.2.3", 1},
+		{"1.2.3_beta", "1.2.3-beta", 1},
+		{"1.2.3beta1", "1.2.3-beta1", 1},
+		{"01.02.03", "1.2.3", 3},
+		{"1.2.3-rc.01+b_1", "1.2.3-rc.1+b-1", 2},
+		{"pkg-1.2.3.tar.gz", "1.2.3", 2},
+		{"  1.2.3  ", "1.2.3", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			v, notes, err := Coerce(tt.in)
+			if err != nil {
+				t.Fatalf("Coerce(%q): %v", tt.in, err)
+			}
+			if got := v.String(); got != tt.want {
+				t.Fatalf("Coerce(%q) = %s, want %s", tt.in, got, tt.want)
+			}
+			if len(notes) != tt.notes {
+				t.Fatalf("Coerce(%q) notes = %q, want %d", tt.in, notes, tt.notes)
+			}
+		})
+	}
+}
+
+func TestCoerce_Errors(t *testing.T) {
+	if _, _, err := Coerce("foo"); !errors.Is(err, ErrNoVersion) {
+		t.Fatalf("expected ErrNoVersion, got %v", err)
+	}
+	_, _, err := Coerce("1.18446744073709551616")
+	var pe *ParseError
+	if !errors.As(err, &pe) || !errors.Is(err, ErrOverflow) || pe.Offset != 2 {
+		t.Fatalf("expected overflow at offset 2, got %v", err)
+	}
+}
@@ -0,0 +1,127 @@
+package types
+
+import (
+	"strings"
+)
+
+// Compare returns -1, 0 or 1 depending on whether v has lower, equal or higher
+// precedence than o, following SemVer 2.0.0 §11. Build metadata is ignored.
+func (v Version) Compare(o Version) int {
+	if c := compareUint(v.Major, o.Major); c != 0 {
+		return c
+	}
+	if c := compareUint(v.Minor, o.Minor); c != 0 {
+		return c
+	}
+	if c := compareUint(v.Patch, o.Patch); c != 0 {
+		return c
+	}
+	return comparePre(v.PreRelease, o.PreRelease)
+}
+
+// Equal reports whether v and o have the same precedence.
+// Two versions that differ only in build metadata are equal.
+func (v Version) Equal(o Version) bool {
+	return v.Compare(o) == 0
+}
+
+// LessThan reports whether v has lower precedence than o.
+func (v Version) LessThan(o Version) bool {
+	return v.Compare(o) < 0
+}
+
+// LessThanOrEqual reports whether v has lower or equal precedence than o.
+func (v Version) LessThanOrEqual(o Version) bool {
+	return v.Compare(o) <= 0
+}
+
+// GreaterThan reports whether v has higher precedence than o.
+func (v Version) GreaterThan(o Version) bool {
+	return v.Compare(o) > 0
+}
+
+// GreaterThanOrEqual reports whether v has higher or equal precedence than o.
+func (v Version) GreaterThanOrEqual(o Version) bool {
+	return v.Compare(o) >= 0
+}
+
+// Compare is a convenience wrapper around a.Compare(b), handy for slices.SortFunc.
+func Compare(a, b Version) int {
+	return a.Compare(b)
+}
+
+func compareUint(a, b uint64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// comparePre compares two dot-separated prerelease strings. A version
+// without a prerelease has higher precedence than one with a prerelease.
+func comparePre(a, b string) int {
+	switch {
+	case a == b:
+		return 0
+	case a == "":
+		return 1
+	case b == "":
+		return -1
+	}
+
+	for {
+		aID, aRest, aMore := strings.Cut(a, ".")
+		bID, bRest, bMore := strings.Cut(b, ".")
+		if c := compareIdentifier(aID, bID); c != 0 {
+			return c
+		}
+		// all shared identifiers are equal; the shorter list sorts first
+		switch {
+		case !aMore && !bMore:
+			return 0
+		case !aMore:
+			return -1
+		case !bMore:
+			return 1
+		}
+		a, b = aRest, bRest
+	}
+}
+
+// compareIdentifier compares a single prerelease identifier. Numeric
+// identifiers compare numerically and always sort before alphanumeric ones,
+// which compare lexically in ASCII order.
+func compareIdentifier(a, b string) int {
+	aNum, bNum := isNumeric(a), isNumeric(b)
+	switch {
+	case aNum && bNum:
+		// no leading zeros, so a longer number is a larger number
+		if len(a) != len(b) {
+			if len(a) < len(b) {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(a, b)
+	case aNum:
+		return -1
+	case bNum:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+func isNumeric(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,102 @@
+package types
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestCompare_SpecPrecedenceOrder(t *testing.T) {
+	// Ordered list from SemVer 2.0.0 §11 plus a few core cases.
+	ordered := []string{
+		"0.9.9",
+		"1.0.0-alpha",
+		"1.0.0-alpha.1",
+		"1.0.0-alpha.beta",
+		"1.0.0-beta",
+		"1.0.0-beta.2",
+		"1.0.0-beta.11",
+		"1.0.0-rc.1",
+		"1.0.0",
+		"1.0.1",
+		"1.1.0",
+		"2.0.0",
+		"2.1.0",
+		"2.1.1",
+		"10.0.0",
+	}
+	for i := range ordered {
+		for j := range ordered {
+			a := NewVersionFromString(ordered[i])
+			b := NewVersionFromString(ordered[j])
+			want := 0
+			if i < j {
+				want = -1
+			} else if i > j {
+				want = 1
+			}
+			if got := a.Compare(b); got != want {
+				t.Errorf("Compare(%s, %s) = %d, want %d", ordered[i], ordered[j], got, want)
+			}
+		}
+	}
+}
+
+func TestCompare_BuildMetadataIgnored(t *testing.T) {
+	a := NewVersionFromString("1.2.3-rc.1+build.1")
+	b := NewVersionFromString("1.2.3-rc.1+build.2")
+	if !a.Equal(b) {
+		t.Fatalf("expected %s and %s to have equal precedence", a.String(), b.String())
+	}
+	if a.LessThan(b) || a.GreaterThan(b) {
+		t.Fatalf("build metadata must not affect ordering")
+	}
+}
+
+func TestCompare_NumericIdentifiers(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"1.0.0-2", "1.0.0-10", -1},
+		{"1.0.0-10", "1.0.0-9", 1},
+		{"1.0.0-1", "1.0.0-a", -1},
+		{"1.0.0-a", "1.0.0-1", 1},
+		{"1.0.0-a.1", "1.0.0-a", 1},
+		{"1.0.0-A", "1.0.0-a", -1},
+		{"1.0.0-rc.1", "1.0.0-rc.1", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.a+"_"+tt.b, func(t *testing.T) {
+			a, b := NewVersionFromString(tt.a), NewVersionFromString(tt.b)
+			if got := a.Compare(b); got != tt.want {
+				t.Fatalf("Compare(%s, %s) = %d, want %d", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompare_Helpers(t *testing.T) {
+	lo := NewVersionFromString("1.2.3")
+	hi := NewVersionFromString("1.3.0")
+	if !lo.LessThan(hi) || !lo.LessThanOrEqual(hi) || !lo.LessThanOrEqual(lo) {
+		t.Fatalf("LessThan helpers disagree with Compare")
+	}
+	if !hi.GreaterThan(lo) || !hi.GreaterThanOrEqual(lo) || !hi.GreaterThanOrEqual(hi) {
+		t.Fatalf("GreaterThan helpers disagree with Compare")
+	}
+}
+
+func TestCompare_SortFunc(t *testing.T) {
+	vs := []Version{
+		NewVersionFromString("1.0.0"),
+		NewVersionFromString("1.0.0-rc.1"),
+		NewVersionFromString("0.1.0"),
+	}
+	slices.SortFunc(vs, Compare)
+	want := []string{"0.1.0", "1.0.0-rc.1", "1.0.0"}
+	for i := range vs {
+		if got := vs[i].String(); got != want[i] {
+			t.Fatalf("index %d: got %s, want %s", i, got, want[i])
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package types
+
+// Change names the most significant component that differs between two
+// versions, as reported by Diff.
+type Change string
+
+const (
+	ChangeNone       Change = "none"
+	ChangeMajor      Change = "major"
+	ChangePremajor   Change = "premajor"
+	ChangeMinor      Change = "minor"
+	ChangePreminor   Change = "preminor"
+	ChangePatch      Change = "patch"
+	ChangePrepatch   Change = "prepatch"
+	ChangePrerelease Change = "prerelease"
+	ChangeBuild      Change = "build"
+)
+
+// Diff reports the most significant component that differs between a and b.
+// A core change is reported with a "pre" prefix (premajor, preminor,
+// prepatch) when the higher of the two versions is a prerelease. Versions
+// that differ only in build metadata report ChangeBuild.
+func Diff(a, b Version) Change {
+	hi := a
+	if a.Compare(b) < 0 {
+		hi = b
+	}
+	pre := hi.IsPrerelease()
+
+	switch {
+	case a.Major != b.Major:
+		if pre {
+			return ChangePremajor
+		}
+		return ChangeMajor
+	case a.Minor != b.Minor:
+		if pre {
+			return ChangePreminor
+		}
+		return ChangeMinor
+	case a.Patch != b.Patch:
+		if pre {
+			return ChangePrepatch
+		}
+		return ChangePatch
+	case comparePre(a.PreRelease, b.PreRelease) != 0:
+		return ChangePrerelease
+	case a.Build != b.Build:
+		return ChangeBuild
+	}
+	return ChangeNone
+}
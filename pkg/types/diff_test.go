@@ -0,0 +1,30 @@
+package types
+
+import "testing"
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want Change
+	}{
+		{"1.2.3", "1.2.3", ChangeNone},
+		{"1.2.3", "2.0.0", ChangeMajor},
+		{"2.0.0", "1.2.3", ChangeMajor},
+		{"1.2.3", "2.0.0-rc.1", ChangePremajor},
+		{"1.2.3", "1.3.0", ChangeMinor},
+		{"1.2.3", "1.3.0-beta.0", ChangePreminor},
+		{"1.2.3", "1.2.4", ChangePatch},
+		{"1.2.3", "1.2.4-0", ChangePrepatch},
+		{"1.2.3-rc.1", "1.2.3-rc.2", ChangePrerelease},
+		{"1.2.3-rc.1", "1.2.3", ChangePrerelease},
+		{"1.2.3+build.1", "1.2.3+build.2", ChangeBuild},
+		{"1.2.3-rc.1+a", "1.2.3-rc.2+b", ChangePrerelease},
+	}
+	for _, tt := range tests {
+		t.Run(tt.a+"_"+tt.b, func(t *testing.T) {
+			if got := Diff(MustParse(tt.a), MustParse(tt.b)); got != tt.want {
+				t.Fatalf("Diff(%s, %s) = %s, want %s", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
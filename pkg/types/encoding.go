@@ -0,0 +1,55 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// fields has Version's layout but none of its methods, so it marshals as a
+// plain object. Json and the legacy object form of UnmarshalJSON use it.
+type fields Version
+
+// MarshalText encodes the version as its canonical string, e.g.
+// "1.2.3-rc.1+build.5". Encoders that honor encoding.TextMarshaler, such as
+// encoding/xml and most YAML libraries, pick this up.
+func (v Version) MarshalText() ([]byte, error) {
+	return []byte(v.String()), nil
+}
+
+// UnmarshalText parses text with Parse and leaves v unchanged on error.
+func (v *Version) UnmarshalText(text []byte) error {
+	parsed, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+	*v = parsed
+	return nil
+}
+
+// MarshalJSON encodes the version as a JSON string.
+func (v Version) MarshalJSON() ([]byte, error) {
+	return json.Marshal(v.String())
+}
+
+// UnmarshalJSON decodes a version string. For compatibility it also accepts
+// the object written by Json, which is checked by formatting it and parsing
+// the result. A JSON null leaves v unchanged.
+func (v *Version) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	switch {
+	case bytes.Equal(data, []byte("null")):
+		return nil
+	case len(data) > 0 && data[0] == '{':
+		var f fields
+		if err := json.Unmarshal(data, &f); err != nil {
+			return err
+		}
+		legacy := Version(f)
+		return v.UnmarshalText([]byte(legacy.String()))
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return v.UnmarshalText([]byte(s))
+}
@@ -0,0 +1,72 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+type config struct {
+	Name    string   `json:"name"`
+	Version Version  `json:"version"`
+	Min     *Version `json:"min,omitempty"`
+}
+
+func TestVersion_MarshalJSON(t *testing.T) {
+	min := MustParse("1.0.0")
+	b, err := json.Marshal(config{Name: "app", Version: MustParse("1.2.3-rc.1+build.5"), Min: &min})
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	want := `{"name":"app","version":"1.2.3-rc.1+build.5","min":"1.0.0"}`
+	if string(b) != want {
+		t.Fatalf("got %s, want %s", b, want)
+	}
+
+	var got config
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if got.Version.Compare(MustParse("1.2.3-rc.1+build.5")) != 0 || got.Version.Build != "build.5" || got.Min.String() != "1.0.0" {
+		t.Fatalf("round-trip mismatch: %+v", got)
+	}
+}
+
+func TestVersion_UnmarshalJSON_Invalid(t *testing.T) {
+	tests := []struct {
+		in   string
+		rule error
+	}{
+		{`{"version":"1.2"}`, ErrMissingComponent},
+		{`{"version":"1.02.3"}`, ErrLeadingZero},
+		{`{"version":{"Major":1,"PreRelease":"rc..1"}}`, ErrEmptyIdentifier},
+		{`{"version":{"Major":1,"Build":"b_1"}}`, ErrBadCharacter},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			var c config
+			if err := json.Unmarshal([]byte(tt.in), &c); !errors.Is(err, tt.rule) {
+				t.Fatalf("expected %v, got %v", tt.rule, err)
+			}
+		})
+	}
+	var c config
+	if err := json.Unmarshal([]byte(`{"version":7}`), &c); err == nil {
+		t.Fatalf("expected a number to be rejected")
+	}
+}
+
+func TestVersion_TextRoundTrip(t *testing.T) {
+	v := MustParse("2.0.0-beta.2")
+	text, err := v.MarshalText()
+	if err != nil || string(text) != "2.0.0-beta.2" {
+		t.Fatalf("MarshalText = %q, %v", text, err)
+	}
+	var got Version
+	if err := got.UnmarshalText(text); err != nil || got.String() != "2.0.0-beta.2" {
+		t.Fatalf("UnmarshalText = %s, %v", got.String(), err)
+	}
+	if err := got.UnmarshalText([]byte("nope")); err == nil || got.String() != "2.0.0-beta.2" {
+		t.Fatalf("invalid text should fail and leave the version unchanged, got %s, %v", got.String(), err)
+	}
+}
@@ -0,0 +1,17 @@
+package types
+
+// Set parses s with Parse, so that *Version implements pflag.Value and
+// flag.Value and a flag such as --min-version is validated at parse time:
+//
+//	var min types.Version
+//	cmd.Flags().Var(&min, "min-version", "lowest supported version")
+//
+// The error is the same ParseError that `semver set` reports.
+func (v *Version) Set(s string) error {
+	return v.UnmarshalText([]byte(s))
+}
+
+// Type names the value in pflag's usage output.
+func (v *Version) Type() string {
+	return "version"
+}
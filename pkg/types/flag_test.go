@@ -0,0 +1,36 @@
+package types
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/spf13/pflag"
+)
+
+var _ pflag.Value = (*Version)(nil)
+
+func TestVersion_FlagValue(t *testing.T) {
+	fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	min := MustParse("1.0.0")
+	fs.Var(&min, "min-version", "lowest supported version")
+
+	if err := fs.Parse([]string{"--min-version", "1.2.0-rc.1"}); err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if got := min.String(); got != "1.2.0-rc.1" {
+		t.Fatalf("min-version = %s", got)
+	}
+	if got := fs.Lookup("min-version").Value.Type(); got != "version" {
+		t.Fatalf("Type() = %q", got)
+	}
+
+	_, want := Parse("1.2")
+	err := fs.Parse([]string{"--min-version", "1.2"})
+	if !errors.Is(err, ErrMissingComponent) || !strings.Contains(err.Error(), want.Error()) {
+		t.Fatalf("expected %q in flag error, got %v", want, err)
+	}
+	if got := min.String(); got != "1.2.0-rc.1" {
+		t.Fatalf("invalid value changed the flag: %s", got)
+	}
+}
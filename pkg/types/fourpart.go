@@ -0,0 +1,188 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ErrNotFourPart is returned by FourPartFromSemVer for versions that carry a
+// prerelease or build metadata other than a revision.
+var ErrNotFourPart = errors.New("not convertible to a four-segment version")
+
+// FourPart is a major.minor.patch.revision version as used by Windows and
+// .NET assemblies, e.g. "1.2.3.4". It has no prerelease or build metadata.
+type FourPart struct {
+	Major    uint64
+	Minor    uint64
+	Patch    uint64
+	Revision uint64
+}
+
+// ParseFourPart parses a four-segment version. Surrounding whitespace and a
+// leading 'v' or 'V' are tolerated as with Parse; components follow the same
+// rules as SemVer's (digits only, no leading zeros, must fit a uint64).
+func ParseFourPart(version string) (FourPart, error) {
+	s, off := trimInput(version)
+	if s == "" {
+		return FourPart{}, &ParseError{Input: version, Offset: off, Err: ErrEmpty}
+	}
+
+	var f FourPart
+	dst := [4]*uint64{&f.Major, &f.Minor, &f.Patch, &f.Revision}
+	start := 0
+	for n := range dst {
+		end := start
+		for end < len(s) && s[end] != '.' {
+			end++
+		}
+		if n < 3 && end == len(s) {
+			return FourPart{}, &ParseError{Input: version, Offset: off + end, Err: ErrMissingComponent}
+		}
+		if n == 3 && end < len(s) {
+			return FourPart{}, &ParseError{Input: version, Offset: off + end, Err: ErrBadCharacter}
+		}
+		num, pos, err := parseComponent(s[start:end])
+		if err != nil {
+			return FourPart{}, &ParseError{Input: version, Offset: off + start + pos, Err: err}
+		}
+		*dst[n] = num
+		start = end + 1
+	}
+	return f, nil
+}
+
+// MustParseFourPart is like ParseFourPart but panics if the version cannot
+// be parsed.
+func MustParseFourPart(version string) FourPart {
+	f, err := ParseFourPart(version)
+	if err != nil {
+		panic(err)
+	}
+	return f
+}
+
+func (f FourPart) String() string {
+	return fmt.Sprintf("%d.%d.%d.%d", f.Major, f.Minor, f.Patch, f.Revision)
+}
+
+// Json returns the version as an indented object with one field per segment.
+func (f FourPart) Json() string {
+	type plain FourPart
+	json, err := json.MarshalIndent(plain(f), "", "  ")
+	if err != nil {
+		return ""
+	}
+	return string(json)
+}
+
+func (f FourPart) PrettyPrint() string {
+	return fmt.Sprintf("{Major: %v, Minor: %v, Patch: %v, Revision: %v}", f.Major, f.Minor, f.Patch, f.Revision)
+}
+
+// Compare returns -1, 0 or +1 depending on whether f is lower than, equal
+// to or higher than o, comparing segment by segment.
+func (f FourPart) Compare(o FourPart) int {
+	for _, c := range [4]int{
+		compareUint(f.Major, o.Major),
+		compareUint(f.Minor, o.Minor),
+		compareUint(f.Patch, o.Patch),
+		compareUint(f.Revision, o.Revision),
+	} {
+		if c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+// NextMajor returns the next major version, e.g. 1.2.3.4 becomes 2.0.0.0.
+func (f FourPart) NextMajor() (FourPart, error) {
+	if f.Major == math.MaxUint64 {
+		return FourPart{}, fmt.Errorf("cannot increment major version %d: %w", f.Major, ErrOverflow)
+	}
+	return FourPart{Major: f.Major + 1}, nil
+}
+
+// NextMinor returns the next minor version, e.g. 1.2.3.4 becomes 1.3.0.0.
+func (f FourPart) NextMinor() (FourPart, error) {
+	if f.Minor == math.MaxUint64 {
+		return FourPart{}, fmt.Errorf("cannot increment minor version %d: %w", f.Minor, ErrOverflow)
+	}
+	return FourPart{Major: f.Major, Minor: f.Minor + 1}, nil
+}
+
+// NextPatch returns the next patch version, e.g. 1.2.3.4 becomes 1.2.4.0.
+func (f FourPart) NextPatch() (FourPart, error) {
+	if f.Patch == math.MaxUint64 {
+		return FourPart{}, fmt.Errorf("cannot increment patch version %d: %w", f.Patch, ErrOverflow)
+	}
+	return FourPart{Major: f.Major, Minor: f.Minor, Patch: f.Patch + 1}, nil
+}
+
+// NextRevision returns the next revision, e.g. 1.2.3.4 becomes 1.2.3.5.
+func (f FourPart) NextRevision() (FourPart, error) {
+	if f.Revision == math.MaxUint64 {
+		return FourPart{}, fmt.Errorf("cannot increment revision %d: %w", f.Revision, ErrOverflow)
+	}
+	f.Revision++
+	return f, nil
+}
+
+// revisionPrefix starts the build metadata that carries a revision.
+const revisionPrefix = "rev."
+
+// SemVer converts f to SemVer, moving the revision into build metadata:
+// 1.2.3.4 becomes 1.2.3+rev.4. A zero revision is dropped, so 1.2.3.0
+// becomes 1.2.3. FourPartFromSemVer reverses the conversion.
+func (f FourPart) SemVer() Version {
+	v := Version{Major: f.Major, Minor: f.Minor, Patch: f.Patch}
+	if f.Revision != 0 {
+		v.Build = revisionPrefix + strconv.FormatUint(f.Revision, 10)
+	}
+	return v
+}
+
+// FourPartFromSemVer converts v to a four-segment version, taking the
+// revision from build metadata of the form "rev.N" and 0 when there is no
+// build. Versions with a prerelease or other build metadata, including
+// "rev.0", which SemVer would not give back, fail with ErrNotFourPart, since
+// the conversion would lose them.
+func FourPartFromSemVer(v Version) (FourPart, error) {
+	f := FourPart{Major: v.Major, Minor: v.Minor, Patch: v.Patch}
+	if v.IsPrerelease() {
+		return FourPart{}, fmt.Errorf("%s: prerelease %q: %w", v.String(), v.PreRelease, ErrNotFourPart)
+	}
+	if v.Build == "" {
+		return f, nil
+	}
+	rev, ok := strings.CutPrefix(v.Build, revisionPrefix)
+	if !ok {
+		return FourPart{}, fmt.Errorf("%s: build %q is not %sN: %w", v.String(), v.Build, revisionPrefix, ErrNotFourPart)
+	}
+	n, _, err := parseComponent(rev)
+	if err != nil {
+		return FourPart{}, fmt.Errorf("%s: revision %q: %w", v.String(), rev, err)
+	}
+	if n == 0 {
+		return FourPart{}, fmt.Errorf("%s: a zero revision has no build metadata: %w", v.String(), ErrNotFourPart)
+	}
+	f.Revision = n
+	return f, nil
+}
+
+func (f FourPart) MarshalText() ([]byte, error) {
+	return []byte(f.String()), nil
+}
+
+func (f *FourPart) UnmarshalText(text []byte) error {
+	parsed, err := ParseFourPart(string(text))
+	if err != nil {
+		return err
+	}
+	*f = parsed
+	return nil
+}
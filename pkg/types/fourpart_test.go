@@ -0,0 +1,103 @@
+package types
+
+import (
+	"errors"
+	"sort"
+	"testing"
+)
+
+func TestParseFourPart(t *testing.T) {
+	f, err := ParseFourPart(" v1.2.3.4\n")
+	if err != nil {
+		t.Fatalf("ParseFourPart: %v", err)
+	}
+	if f != (FourPart{1, 2, 3, 4}) || f.String() != "1.2.3.4" {
+		t.Fatalf("got %+v (%s)", f, f.String())
+	}
+
+	tests := []struct {
+		in     string
+		rule   error
+		offset int
+	}{
+		{"", ErrEmpty, 0},
+		{"1.2.3", ErrMissingComponent, 5},
+		{"1.2.3.4.5", ErrBadCharacter, 7},
+		{"1.2.3.04", ErrLeadingZero, 6},
+		{"1.2..4", ErrEmptyIdentifier, 4},
+		{"1.2.3.4-rc", ErrBadCharacter, 7},
+		{"1.2.3.18446744073709551616", ErrOverflow, 6},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			_, err := ParseFourPart(tt.in)
+			var pe *ParseError
+			if !errors.As(err, &pe) || !errors.Is(err, tt.rule) || pe.Offset != tt.offset {
+				t.Fatalf("expected %v at %d, got %v", tt.rule, tt.offset, err)
+			}
+		})
+	}
+}
+
+func TestFourPart_CompareAndNext(t *testing.T) {
+	in := []string{"1.10.0.0", "1.2.3.10", "1.2.3.9", "0.9.9.9", "1.2.3.9"}
+	list := make([]FourPart, len(in))
+	for i, s := range in {
+		list[i] = MustParseFourPart(s)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Compare(list[j]) < 0 })
+	want := []string{"0.9.9.9", "1.2.3.9", "1.2.3.9", "1.2.3.10", "1.10.0.0"}
+	for i, f := range list {
+		if f.String() != want[i] {
+			t.Fatalf("sorted[%d] = %s, want %s", i, f.String(), want[i])
+		}
+	}
+
+	f := MustParseFourPart("1.2.3.4")
+	for _, tt := range []struct {
+		next func() (FourPart, error)
+		want string
+	}{
+		{f.NextRevision, "1.2.3.5"},
+		{f.NextPatch, "1.2.4.0"},
+		{f.NextMinor, "1.3.0.0"},
+		{f.NextMajor, "2.0.0.0"},
+	} {
+		if got, err := tt.next(); err != nil || got.String() != tt.want {
+			t.Fatalf("got %s (%v), want %s", got.String(), err, tt.want)
+		}
+	}
+	if _, err := (FourPart{Revision: 1<<64 - 1}).NextRevision(); !errors.Is(err, ErrOverflow) {
+		t.Fatalf("expected ErrOverflow, got %v", err)
+	}
+}
+
+func TestFourPart_SemVerConversion(t *testing.T) {
+	for in, want := range map[string]string{
+		"1.2.3.4": "1.2.3+rev.4",
+		"1.2.3.0": "1.2.3",
+	} {
+		f := MustParseFourPart(in)
+		v := f.SemVer()
+		if got := v.String(); got != want {
+			t.Fatalf("%s.SemVer() = %s, want %s", in, got, want)
+		}
+		back, err := FourPartFromSemVer(v)
+		if err != nil || back != f {
+			t.Fatalf("FourPartFromSemVer(%s) = %s (%v), want %s", want, back.String(), err, in)
+		}
+	}
+
+	for _, in := range []string{"1.2.3-rc.1", "1.2.3+build.5", "1.2.3+rev.4.5"} {
+		if _, err := FourPartFromSemVer(MustParse(in)); err == nil {
+			t.Fatalf("FourPartFromSemVer(%s) should fail", in)
+		}
+	}
+	// 1.2.3.0 converts to 1.2.3, so +rev.0 would not round-trip
+	if _, err := FourPartFromSemVer(MustParse("1.2.3+rev.0")); !errors.Is(err, ErrNotFourPart) {
+		t.Fatalf("expected ErrNotFourPart, got %v", err)
+	}
+	if _, err := FourPartFromSemVer(MustParse("1.2.3+rev.04")); !errors.Is(err, ErrLeadingZero) {
+		t.Fatalf("expected ErrLeadingZero, got %v", err)
+	}
+}
@@ -0,0 +1,204 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/dp1140a/semver/pkg/util"
+)
+
+var fuzzSeeds = []string{
+	"1.2.3",
+	"v1.2.3",
+	" 1.2.3\n",
+	"0.0.0",
+	"1.0.0-alpha.1+exp.sha.5114f85",
+	"1.0.0-x-y-z.--",
+	"1.0.0-0a.00a.-0",
+	"18446744073709551615.0.0",
+	"18446744073709551616.0.0",
+	"1.2.3-rc.18446744073709551616",
+	"01.2.3",
+	"1.2",
+	"1.2.3+",
+	"v 0.0.0",
+	"",
+}
+
+// FuzzParse checks that Parse, NewVersionFromString and
+// util.ValidVersionString agree, and that a parsed version prints back as
+// its input and parses back to itself.
+func FuzzParse(f *testing.F) {
+	for _, s := range fuzzSeeds {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		v, err := Parse(s)
+		if nv := NewVersionFromString(s); nv != v {
+			t.Fatalf("NewVersionFromString(%q) = %+v, Parse = %+v (%v)", s, nv, v, err)
+		}
+
+		// rest is what Parse scans; ValidVersionString would also trim the
+		// whitespace of "v 1.2.3" that Parse rejects after the 'v'
+		rest, _ := trimInput(s)
+		valid := rest == strings.TrimSpace(rest) && util.ValidVersionString(rest)
+		if valid != (err == nil) {
+			t.Fatalf("ValidVersionString(%q) = %v, Parse error = %v", rest, valid, err)
+		}
+		if err != nil {
+			var pe *ParseError
+			if !errors.As(err, &pe) || pe.Input != s || pe.Offset < 0 || pe.Offset > len(s) {
+				t.Fatalf("Parse(%q): bad error %#v", s, err)
+			}
+			return
+		}
+
+		if got := v.String(); got != rest {
+			t.Fatalf("Parse(%q).String() = %q, want %q", s, got, rest)
+		}
+		again, err := Parse(v.String())
+		if err != nil || again != v {
+			t.Fatalf("re-parse of %q = %+v (%v), want %+v", v.String(), again, err, v)
+		}
+		if v.Compare(again) != 0 {
+			t.Fatalf("%q does not compare equal to itself", s)
+		}
+	})
+}
+
+// FuzzMarshal checks that JSON and text encoding round-trip every valid
+// version and reject everything Parse rejects.
+func FuzzMarshal(f *testing.F) {
+	for _, s := range fuzzSeeds {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		data, err := json.Marshal(s)
+		if err != nil {
+			t.Skip()
+		}
+		var v Version
+		jerr := json.Unmarshal(data, &v)
+		want, perr := Parse(s)
+		if (jerr == nil) != (perr == nil) {
+			t.Fatalf("UnmarshalJSON(%s) err = %v, Parse err = %v", data, jerr, perr)
+		}
+		if perr != nil {
+			return
+		}
+		if v != want {
+			t.Fatalf("UnmarshalJSON(%s) = %+v, want %+v", data, v, want)
+		}
+		out, err := json.Marshal(v)
+		if err != nil {
+			t.Fatalf("MarshalJSON: %v", err)
+		}
+		var back Version
+		if err := json.Unmarshal(out, &back); err != nil || back != v {
+			t.Fatalf("round-trip through %s = %+v (%v)", out, back, err)
+		}
+	})
+}
+
+// FuzzCoerce checks that Coerce never panics, always returns a valid
+// version, and leaves valid input untouched.
+func FuzzCoerce(f *testing.F) {
+	for _, s := range append(fuzzSeeds, "release-1.2.3", "1.2.3.4_beta", "pkg-01.2.tar.gz", "1.2.3-rc.01+b_1") {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		v, notes, err := Coerce(s)
+		if err != nil {
+			return
+		}
+		if _, perr := Parse(v.String()); perr != nil {
+			t.Fatalf("Coerce(%q) = %q, which does not parse: %v", s, v.String(), perr)
+		}
+		if want, perr := Parse(s); perr == nil {
+			if v != want || len(notes) != 0 {
+				t.Fatalf("Coerce(%q) = %+v %q, want %+v unchanged", s, v, notes, want)
+			}
+		}
+	})
+}
+
+// FuzzCompare checks that Compare is antisymmetric and agrees with SortKey.
+func FuzzCompare(f *testing.F) {
+	f.Add("1.0.0-alpha", "1.0.0-alpha.1")
+	f.Add("1.0.0-a-", "1.0.0-a.1")
+	f.Add("1.0.0-2", "1.0.0-10")
+	f.Add("1.0.0+a", "1.0.0+b")
+	f.Fuzz(func(t *testing.T, a, b string) {
+		va, err := Parse(a)
+		if err != nil {
+			return
+		}
+		vb, err := Parse(b)
+		if err != nil {
+			return
+		}
+		cmp := va.Compare(vb)
+		if vb.Compare(va) != -cmp {
+			t.Fatalf("Compare(%s, %s) = %d but reverse is %d", a, b, cmp, vb.Compare(va))
+		}
+		ka, err := va.SortKey()
+		if err != nil {
+			t.Fatalf("SortKey(%s): %v", a, err)
+		}
+		kb, err := vb.SortKey()
+		if err != nil {
+			t.Fatalf("SortKey(%s): %v", b, err)
+		}
+		if keys := strings.Compare(ka, kb); keys != cmp {
+			t.Fatalf("Compare(%s, %s) = %d but SortKey order is %d", a, b, cmp, keys)
+		}
+	})
+}
+
+// FuzzParsePartial checks that a partial prints back as its input, that
+// every full version is a partial covering itself, and that Range never
+// panics.
+func FuzzParsePartial(f *testing.F) {
+	for _, s := range append(fuzzSeeds, "*", "1.x", "1.2.*", "1.X.x", "1.x.3", "18446744073709551615.x") {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		p, err := ParsePartial(s)
+		v, verr := Parse(s)
+		if verr == nil && (err != nil || p.Given() != 3 || !p.Contains(v)) {
+			t.Fatalf("ParsePartial(%q) = %+v (%v), want a full partial containing %s", s, p, err, v.String())
+		}
+		if err != nil {
+			return
+		}
+		if p.String() != strings.TrimSpace(s) {
+			t.Fatalf("ParsePartial(%q).String() = %q", s, p.String())
+		}
+		if r, err := p.Range(); err == nil && r.Lower != nil && !r.Contains(*r.Lower) {
+			t.Fatalf("range %s of %q does not contain its lower bound", r, s)
+		}
+	})
+}
+
+// FuzzPEP440 checks that every version PEP440 accepts reads back unchanged.
+func FuzzPEP440(f *testing.F) {
+	for _, s := range append(fuzzSeeds, "1.2.0-rc.1", "1.2.0-alpha.0", "1.2.0-beta.7+local.1", "1.2.0-a.1", "1.2.0-beta") {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		v, err := Parse(s)
+		if err != nil {
+			return
+		}
+		pep, err := v.PEP440()
+		if err != nil {
+			return
+		}
+		back, err := ParsePEP440(pep)
+		if err != nil || back.String() != v.String() {
+			t.Fatalf("%s was written as %q and read back as %s (%v)", v.String(), pep, back.String(), err)
+		}
+	})
+}
@@ -0,0 +1,73 @@
+package types
+
+import "github.com/dp1140a/semver/pkg/util"
+
+// The methods in this file are value-returning counterparts of the pointer
+// mutators. They work on a copy, so a Version shared between goroutines can
+// be used to derive new versions without locking, and they return the zero
+// Version with the error when the input is invalid.
+
+// NextMajor returns the next major version, e.g. 1.2.3-rc.1 becomes 2.0.0.
+func (v Version) NextMajor() (Version, error) {
+	if err := v.IncrementMajor(); err != nil {
+		return Version{}, err
+	}
+	return v, nil
+}
+
+// NextMinor returns the next minor version, e.g. 1.2.3 becomes 1.3.0.
+func (v Version) NextMinor() (Version, error) {
+	if err := v.IncrementMinor(); err != nil {
+		return Version{}, err
+	}
+	return v, nil
+}
+
+// NextPatch returns the next patch version, e.g. 1.2.3 becomes 1.2.4.
+func (v Version) NextPatch() (Version, error) {
+	if err := v.IncrementPatch(); err != nil {
+		return Version{}, err
+	}
+	return v, nil
+}
+
+// NextPre returns the next prerelease in the preid series; see IncrementPre.
+func (v Version) NextPre(preid string) (Version, error) {
+	if err := v.IncrementPre(preid); err != nil {
+		return Version{}, err
+	}
+	return v, nil
+}
+
+// WithPre returns v with its prerelease replaced by pre; the empty string
+// removes it. Build metadata is kept. Unlike SetPre, the prerelease is
+// validated.
+func (v Version) WithPre(pre string) (Version, error) {
+	if _, err := ParsePreRelease(pre); err != nil {
+		return Version{}, err
+	}
+	v.SetPre(pre)
+	return v, nil
+}
+
+// WithBuild returns v with its build metadata replaced by build; the empty
+// string removes it. Unlike SetBuild, the metadata is validated.
+func (v Version) WithBuild(build string) (Version, error) {
+	if err := validateBuild(build); err != nil {
+		return Version{}, err
+	}
+	v.SetBuild(build)
+	return v, nil
+}
+
+// validateBuild checks build metadata: dot-separated, non-empty identifiers
+// of [0-9A-Za-z-]. Leading zeros are allowed.
+func validateBuild(build string) error {
+	if build == "" {
+		return nil
+	}
+	if pos, err := util.ScanBuild(build); err != nil {
+		return &ParseError{Input: build, Offset: pos, Err: err}
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package types
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestVersion_Next(t *testing.T) {
+	v := MustParse("1.2.3-rc.1+build.5")
+	tests := []struct {
+		name string
+		next func() (Version, error)
+		want string
+	}{
+		{"major", v.NextMajor, "2.0.0"},
+		{"minor", v.NextMinor, "1.3.0"},
+		{"patch", v.NextPatch, "1.2.4"},
+		{"pre", func() (Version, error) { return v.NextPre("rc") }, "1.2.3-rc.2"},
+		{"with pre", func() (Version, error) { return v.WithPre("beta.1") }, "1.2.3-beta.1+build.5"},
+		{"clear pre", func() (Version, error) { return v.WithPre("") }, "1.2.3+build.5"},
+		{"with build", func() (Version, error) { return v.WithBuild("sha.0a1b") }, "1.2.3-rc.1+sha.0a1b"},
+		{"clear build", func() (Version, error) { return v.WithBuild("") }, "1.2.3-rc.1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.next()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.String() != tt.want {
+				t.Fatalf("got %s, want %s", got.String(), tt.want)
+			}
+			if v.String() != "1.2.3-rc.1+build.5" {
+				t.Fatalf("receiver was modified: %s", v.String())
+			}
+		})
+	}
+}
+
+func TestVersion_NextErrors(t *testing.T) {
+	v := Version{Major: math.MaxUint64}
+	if got, err := v.NextMajor(); !errors.Is(err, ErrOverflow) || got.String() != "0.0.0" {
+		t.Fatalf("NextMajor = %s, %v; want zero Version and ErrOverflow", got.String(), err)
+	}
+	if _, err := v.WithPre("rc..1"); !errors.Is(err, ErrEmptyIdentifier) {
+		t.Fatalf("WithPre: expected ErrEmptyIdentifier, got %v", err)
+	}
+	if _, err := v.NextPre("rc_1"); !errors.Is(err, ErrBadCharacter) {
+		t.Fatalf("NextPre: expected ErrBadCharacter, got %v", err)
+	}
+	if got, err := (Version{Major: 1, PreRelease: "rc..1"}).NextPre(""); !errors.Is(err, ErrEmptyIdentifier) {
+		t.Fatalf("NextPre on an invalid prerelease = %s, %v; want ErrEmptyIdentifier", got.String(), err)
+	}
+
+	tests := []struct {
+		build  string
+		rule   error
+		offset int
+	}{
+		{"build..5", ErrEmptyIdentifier, 6},
+		{"build.", ErrEmptyIdentifier, 6},
+		{"sha_1", ErrBadCharacter, 3},
+	}
+	for _, tt := range tests {
+		_, err := v.WithBuild(tt.build)
+		var pe *ParseError
+		if !errors.As(err, &pe) || !errors.Is(err, tt.rule) || pe.Offset != tt.offset {
+			t.Fatalf("WithBuild(%q): expected %v at %d, got %v", tt.build, tt.rule, tt.offset, err)
+		}
+	}
+}
+
+func TestVersion_NextNoAliasing(t *testing.T) {
+	v := MustParse("1.0.0-rc.1")
+	a, _ := v.NextPre("rc")
+	b, _ := v.NextPre("rc")
+	a.SetPre("9")
+	if v.String() != "1.0.0-rc.1" || b.String() != "1.0.0-rc.2" {
+		t.Fatalf("derived versions share state: v=%s b=%s", v.String(), b.String())
+	}
+}
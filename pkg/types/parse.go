@@ -0,0 +1,98 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/dp1140a/semver/pkg/util"
+)
+
+// Rules a version string can break. A *ParseError wraps exactly one of these,
+// so callers can test for a specific rule with errors.Is. They are the same
+// values util.ScanVersion returns.
+var (
+	ErrEmpty            = util.ErrEmpty
+	ErrMissingComponent = util.ErrMissingComponent
+	ErrLeadingZero      = util.ErrLeadingZero
+	ErrEmptyIdentifier  = util.ErrEmptyIdentifier
+	ErrBadCharacter     = util.ErrBadCharacter
+)
+
+// ParseError describes why a string is not a valid semantic version.
+type ParseError struct {
+	Input  string // the string passed to Parse
+	Offset int    // byte offset into Input where the problem was found
+	Err    error  // the rule that was broken, one of the Err* values
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("invalid version %q: %v at offset %d", e.Input, e.Err, e.Offset)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// Parse parses a SemVer 2.0.0 version string. Surrounding whitespace and a
+// single leading 'v' or 'V' are tolerated, as with NewVersionFromString;
+// anything else that does not follow the spec returns a *ParseError.
+func Parse(version string) (Version, error) {
+	s, off := trimInput(version)
+	if s == "" {
+		return Version{}, &ParseError{Input: version, Offset: off, Err: ErrEmpty}
+	}
+
+	sp, pos, err := util.ScanVersion(s)
+	if err != nil {
+		return Version{}, &ParseError{Input: version, Offset: off + pos, Err: err}
+	}
+
+	semver := Version{}
+	dst := [3]*uint64{&semver.Major, &semver.Minor, &semver.Patch}
+	for i, span := range [3]util.Span{sp.Major, sp.Minor, sp.Patch} {
+		n, err := parseInt(s[span.Start:span.End])
+		if err != nil {
+			return Version{}, &ParseError{Input: version, Offset: off + span.Start, Err: err}
+		}
+		*dst[i] = n
+	}
+	if sp.PreRelease.Start >= 0 {
+		pre := s[sp.PreRelease.Start:sp.PreRelease.End]
+		if pos, err := validatePreRelease(pre); err != nil {
+			return Version{}, &ParseError{Input: version, Offset: off + sp.PreRelease.Start + pos, Err: err}
+		}
+		semver.PreRelease = pre
+	}
+	if sp.Build.Start >= 0 {
+		semver.Build = s[sp.Build.Start:sp.Build.End]
+	}
+	return semver, nil
+}
+
+// MustParse is like Parse but panics if the version cannot be parsed.
+func MustParse(version string) Version {
+	v, err := Parse(version)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// SplitPrefix splits the leading 'v' or 'V' that Parse and
+// NewVersionFromString tolerate off a version string.
+func SplitPrefix(version string) (prefix, rest string) {
+	if len(version) > 0 && (version[0] == 'v' || version[0] == 'V') {
+		return version[:1], version[1:]
+	}
+	return "", version
+}
+
+// trimInput strips surrounding whitespace and a leading 'v'/'V', returning
+// what is left and its offset into the original string.
+func trimInput(version string) (string, int) {
+	s := strings.TrimLeftFunc(version, unicode.IsSpace)
+	off := len(version) - len(s)
+	prefix, s := SplitPrefix(strings.TrimRightFunc(s, unicode.IsSpace))
+	return s, off + len(prefix)
+}
@@ -0,0 +1,111 @@
+package types
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParse_Valid(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"1.2.3", "1.2.3"},
+		{" 1.2.3\n", "1.2.3"},
+		{"v1.2.3", "1.2.3"},
+		{"V0.0.0", "0.0.0"},
+		{"1.0.0-alpha.1", "1.0.0-alpha.1"},
+		{"1.0.0-x-y-z.--", "1.0.0-x-y-z.--"},
+		{"1.0.0+21AF26D3----117B344092BD", "1.0.0+21AF26D3----117B344092BD"},
+		{"1.0.0-beta+exp.sha.5114f85", "1.0.0-beta+exp.sha.5114f85"},
+		{"1.0.0+001", "1.0.0+001"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			v, err := Parse(tt.in)
+			if err != nil {
+				t.Fatalf("Parse(%q): %v", tt.in, err)
+			}
+			if got := v.String(); got != tt.want {
+				t.Fatalf("Parse(%q) = %s, want %s", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		in     string
+		rule   error
+		offset int
+	}{
+		{"", ErrEmpty, 0},
+		{"  v", ErrEmpty, 3},
+		{"garbage", ErrBadCharacter, 0},
+		{"1.2", ErrMissingComponent, 3},
+		{"1.2-rc.1", ErrMissingComponent, 3},
+		{"1..3", ErrEmptyIdentifier, 2},
+		{"01.2.3", ErrLeadingZero, 0},
+		{"v1.02.3", ErrLeadingZero, 3},
+		{"1.2.3-rc.01", ErrLeadingZero, 9},
+		{"1.2.3-", ErrEmptyIdentifier, 6},
+		{"1.2.3-rc..1", ErrEmptyIdentifier, 9},
+		{"1.2.3+", ErrEmptyIdentifier, 6},
+		{"1.2.3+build..1", ErrEmptyIdentifier, 12},
+		{"1.2.3-rc_1", ErrBadCharacter, 8},
+		{"1.2.3 beta", ErrBadCharacter, 5},
+		{"1.a.3", ErrBadCharacter, 2},
+		{"\f\v01.2.3", ErrLeadingZero, 2},
+		{"\u00a0v1.2", ErrMissingComponent, 6},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			v, err := Parse(tt.in)
+			if err == nil {
+				t.Fatalf("Parse(%q) = %s, want error", tt.in, v.String())
+			}
+			var pe *ParseError
+			if !errors.As(err, &pe) {
+				t.Fatalf("expected *ParseError, got %T", err)
+			}
+			if !errors.Is(err, tt.rule) {
+				t.Fatalf("Parse(%q) rule = %v, want %v", tt.in, pe.Err, tt.rule)
+			}
+			if pe.Offset != tt.offset {
+				t.Fatalf("Parse(%q) offset = %d, want %d", tt.in, pe.Offset, tt.offset)
+			}
+			if pe.Input != tt.in {
+				t.Fatalf("Parse(%q) input = %q", tt.in, pe.Input)
+			}
+		})
+	}
+}
+
+func TestMustParse_Panics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("expected panic")
+		}
+	}()
+	MustParse("nope")
+}
+
+func TestParse_NoAllocs(t *testing.T) {
+	allocs := testing.AllocsPerRun(100, func() {
+		_, _ = Parse("v10.20.30-beta.11+build.5")
+	})
+	if allocs != 0 {
+		t.Fatalf("Parse allocated %v times, want 0", allocs)
+	}
+}
+
+func BenchmarkParse(b *testing.B) {
+	for _, in := range []string{"10.20.30", "1.0.0-beta.11+exp.sha.5114f85"} {
+		b.Run(in, func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				_, _ = Parse(in)
+			}
+		})
+	}
+}
@@ -0,0 +1,205 @@
+package types
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/dp1140a/semver/pkg/util"
+)
+
+// ErrNumberAfterWildcard is returned for partial versions such as "1.x.3",
+// where a component follows a wildcard.
+var ErrNumberAfterWildcard = errors.New("number after wildcard")
+
+// Partial is a version whose trailing components may be missing or
+// wildcards: "1", "1.2", "1.x", "1.2.*" or "*". A full version, with or
+// without prerelease and build, is also a valid Partial. Missing components
+// read as 0. Partials are made by ParsePartial; the zero Partial is "*".
+// Read the components with Version and Given.
+type Partial struct {
+	v     Version
+	raw   string
+	given int
+}
+
+// ParsePartial parses a partial version. Surrounding whitespace and a
+// leading 'v' or 'V' are tolerated as with Parse. A prerelease or build is
+// only allowed on a full major.minor.patch.
+func ParsePartial(s string) (Partial, error) {
+	rest, off := trimInput(s)
+	if rest == "" {
+		return Partial{}, &ParseError{Input: s, Offset: off, Err: ErrEmpty}
+	}
+	p := Partial{raw: strings.TrimSpace(s)}
+
+	if strings.ContainsAny(rest, "-+") {
+		v, err := Parse(s)
+		if err != nil {
+			return Partial{}, err
+		}
+		p.v = v
+		p.given = 3
+		return p, nil
+	}
+
+	dst := [3]*uint64{&p.v.Major, &p.v.Minor, &p.v.Patch}
+	wild := false
+	for n, start := 0, 0; start <= len(rest); n++ {
+		end := start
+		for end < len(rest) && rest[end] != '.' {
+			end++
+		}
+		part := rest[start:end]
+		switch {
+		case n == 3:
+			return Partial{}, &ParseError{Input: s, Offset: off + start - 1, Err: ErrBadCharacter}
+		case isWildcard(part):
+			wild = true
+		case wild:
+			return Partial{}, &ParseError{Input: s, Offset: off + start, Err: ErrNumberAfterWildcard}
+		default:
+			num, pos, err := parseComponent(part)
+			if err != nil {
+				return Partial{}, &ParseError{Input: s, Offset: off + start + pos, Err: err}
+			}
+			*dst[n] = num
+			p.given = n + 1
+		}
+		start = end + 1
+	}
+	return p, nil
+}
+
+// MustParsePartial is like ParsePartial but panics if s cannot be parsed.
+func MustParsePartial(s string) Partial {
+	p, err := ParsePartial(s)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+func isWildcard(s string) bool {
+	return s == "x" || s == "X" || s == "*"
+}
+
+// parseComponent parses one numeric component, returning the offset of the
+// problem within s on error.
+func parseComponent(s string) (uint64, int, error) {
+	if s == "" {
+		return 0, 0, ErrEmptyIdentifier
+	}
+	for i := 0; i < len(s); i++ {
+		if !util.IsDigit(s[i]) {
+			return 0, i, ErrBadCharacter
+		}
+	}
+	if len(s) > 1 && s[0] == '0' {
+		return 0, 0, ErrLeadingZero
+	}
+	n, err := parseInt(s)
+	if err != nil {
+		return 0, 0, err
+	}
+	return n, 0, nil
+}
+
+// Given returns how many leading components were given as numbers: 0 for
+// "*", 1 for "1" or "1.x", 2 for "1.2" or "1.2.*" and 3 for a full version.
+func (p Partial) Given() int {
+	return p.given
+}
+
+// String returns the partial in the form it was parsed from, e.g. "1.4.x".
+func (p Partial) String() string {
+	if p.raw == "" {
+		return "*"
+	}
+	return p.raw
+}
+
+// Version returns p with missing components filled in as 0.
+func (p Partial) Version() Version {
+	return p.v
+}
+
+// Range returns the versions p covers: "1.4.x" expands to
+// >=1.4.0 <1.5.0-0, "1" to >=1.0.0 <2.0.0-0 and "*" to every version. A
+// full version covers only itself and its builds. It fails with ErrOverflow
+// when the upper bound does not fit, as for "18446744073709551615.x".
+func (p Partial) Range() (Range, error) {
+	if p.given == 0 {
+		return Range{}, nil
+	}
+	lower := p.Version()
+	lower.Build = ""
+	upper := lower
+	var err error
+	switch p.given {
+	case 1:
+		err = upper.IncrementMajor()
+	case 2:
+		err = upper.IncrementMinor()
+	default:
+		if lower.IsPrerelease() {
+			// nothing sorts between 1.2.3-rc.1 and 1.2.3-rc.1.0
+			upper.PreRelease = lower.PreRelease + ".0"
+			return Range{Lower: &lower, Upper: &upper}, nil
+		}
+		err = upper.IncrementPatch()
+	}
+	if err != nil {
+		return Range{}, err
+	}
+	upper.PreRelease = "0"
+	return Range{Lower: &lower, Upper: &upper}, nil
+}
+
+// Contains reports whether v falls in p's range. Unlike a constraint it does
+// not apply npm's prerelease rule, so "1.x" contains "1.5.0-rc.1".
+func (p Partial) Contains(v Version) bool {
+	r, err := p.Range()
+	if err != nil {
+		// only the upper bound overflowed, so everything above the lower one matches
+		return v.Compare(p.Version()) >= 0
+	}
+	return r.Contains(v)
+}
+
+func (p Partial) MarshalText() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+func (p *Partial) UnmarshalText(text []byte) error {
+	parsed, err := ParsePartial(string(text))
+	if err != nil {
+		return err
+	}
+	*p = parsed
+	return nil
+}
+
+// Range is the half-open interval Lower <= v < Upper. A nil bound is open.
+type Range struct {
+	Lower, Upper *Version
+}
+
+// Contains reports whether v falls within r.
+func (r Range) Contains(v Version) bool {
+	return (r.Lower == nil || v.Compare(*r.Lower) >= 0) && (r.Upper == nil || v.Compare(*r.Upper) < 0)
+}
+
+// String formats r as a range expression, e.g. ">=1.4.0 <1.5.0-0" or "*".
+func (r Range) String() string {
+	var parts []string
+	if r.Lower != nil {
+		parts = append(parts, ">="+r.Lower.String())
+	}
+	if r.Upper != nil {
+		parts = append(parts, "<"+r.Upper.String())
+	}
+	if len(parts) == 0 {
+		return "*"
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,123 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestParsePartial(t *testing.T) {
+	tests := []struct {
+		in    string
+		given int
+		rng   string
+	}{
+		{"*", 0, "*"},
+		{"x", 0, "*"},
+		{"1", 1, ">=1.0.0 <2.0.0-0"},
+		{"v1.x", 1, ">=1.0.0 <2.0.0-0"},
+		{"1.X.x", 1, ">=1.0.0 <2.0.0-0"},
+		{"1.4", 2, ">=1.4.0 <1.5.0-0"},
+		{"1.4.x", 2, ">=1.4.0 <1.5.0-0"},
+		{"1.2.*", 2, ">=1.2.0 <1.3.0-0"},
+		{"1.2.3", 3, ">=1.2.3 <1.2.4-0"},
+		{"1.2.3-rc.1+b.5", 3, ">=1.2.3-rc.1 <1.2.3-rc.1.0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			p, err := ParsePartial(tt.in)
+			if err != nil {
+				t.Fatalf("ParsePartial(%q): %v", tt.in, err)
+			}
+			if p.String() != tt.in || p.Given() != tt.given {
+				t.Fatalf("got %q given=%d, want %q given=%d", p.String(), p.Given(), tt.in, tt.given)
+			}
+			r, err := p.Range()
+			if err != nil || r.String() != tt.rng {
+				t.Fatalf("Range() = %s (%v), want %s", r, err, tt.rng)
+			}
+		})
+	}
+}
+
+func TestParsePartial_Errors(t *testing.T) {
+	tests := []struct {
+		in     string
+		rule   error
+		offset int
+	}{
+		{"", ErrEmpty, 0},
+		{"1.x.3", ErrNumberAfterWildcard, 4},
+		{"1.2.3.4", ErrBadCharacter, 5},
+		{"01.2", ErrLeadingZero, 0},
+		{"1..2", ErrEmptyIdentifier, 2},
+		{"v1.2b", ErrBadCharacter, 4},
+		{"1.2-rc.1", ErrMissingComponent, 3},
+		{"1.18446744073709551616", ErrOverflow, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			_, err := ParsePartial(tt.in)
+			var pe *ParseError
+			if !errors.As(err, &pe) || !errors.Is(err, tt.rule) || pe.Offset != tt.offset {
+				t.Fatalf("expected %v at %d, got %v", tt.rule, tt.offset, err)
+			}
+		})
+	}
+}
+
+func TestPartial_Contains(t *testing.T) {
+	p := MustParsePartial("1.4.x")
+	for v, want := range map[string]bool{
+		"1.4.0":      true,
+		"1.4.9+b.1":  true,
+		"1.5.0-rc.1": false,
+		"1.5.0":      false,
+		"1.3.9":      false,
+		"1.4.2-rc.1": true,
+	} {
+		if got := p.Contains(MustParse(v)); got != want {
+			t.Fatalf("1.4.x contains %s = %v, want %v", v, got, want)
+		}
+	}
+	if !MustParsePartial("*").Contains(MustParse("0.0.0-0")) {
+		t.Fatalf("* should contain every version")
+	}
+
+	top := MustParsePartial("18446744073709551615.x")
+	if _, err := top.Range(); !errors.Is(err, ErrOverflow) {
+		t.Fatalf("expected ErrOverflow, got %v", err)
+	}
+	if !top.Contains(MustParse("18446744073709551615.3.0")) {
+		t.Fatalf("overflowing partial should still contain its line")
+	}
+}
+
+func TestPartial_MarshalText(t *testing.T) {
+	var manifest struct {
+		Pin Partial `json:"pin"`
+	}
+	if err := json.Unmarshal([]byte(`{"pin":"1.4.x"}`), &manifest); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if !manifest.Pin.Contains(MustParse("1.4.7")) {
+		t.Fatalf("pin does not contain 1.4.7")
+	}
+	b, err := json.Marshal(manifest)
+	if err != nil || string(b) != `{"pin":"1.4.x"}` {
+		t.Fatalf("marshal = %s, %v", b, err)
+	}
+	if err := json.Unmarshal([]byte(`{"pin":"1.x.4"}`), &manifest); !errors.Is(err, ErrNumberAfterWildcard) {
+		t.Fatalf("expected ErrNumberAfterWildcard, got %v", err)
+	}
+}
+
+func TestPartial_ZeroValue(t *testing.T) {
+	var p Partial
+	if p.String() != "*" || p.Given() != 0 || p.Version() != (Version{}) {
+		t.Fatalf("zero Partial should be *, got %q given=%d", p.String(), p.Given())
+	}
+	if !p.Contains(MustParse("3.1.4")) {
+		t.Fatalf("zero Partial should contain every version")
+	}
+}
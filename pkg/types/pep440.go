@@ -0,0 +1,81 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ErrNotPEP440 is returned when a version has no PEP 440 equivalent, or a
+// PEP 440 version has no SemVer one.
+var ErrNotPEP440 = errors.New("not convertible to or from PEP 440")
+
+// pep440Phases maps the SemVer prerelease channels PEP440 accepts to PEP 440
+// phases.
+var pep440Phases = map[string]string{"alpha": "a", "beta": "b", "rc": "rc"}
+
+// semverChannels maps the PEP 440 phase spellings, normalized, back.
+var semverChannels = map[string]string{"a": "alpha", "alpha": "alpha", "b": "beta", "beta": "beta", "c": "rc", "rc": "rc", "pre": "rc", "preview": "rc"}
+
+// PEP440 renders v the way Python packaging expects: 1.2.0-rc.1 becomes
+// 1.2.0rc1, and alpha.N and beta.N become aN and bN. Only forms that
+// ParsePEP440 turns back into v are accepted; any other prerelease (such as
+// 1.2.0-alpha without a number, or 1.2.0-a.1) and build metadata other than
+// lowercase letters, digits and dots fail with ErrNotPEP440.
+func (v Version) PEP440() (string, error) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.IsPrerelease() {
+		pre := v.Pre()
+		phase, ok := pep440Phases[pre.Channel()]
+		if !ok || len(pre) != 2 || !pre[1].IsNumeric() {
+			return "", fmt.Errorf("%s: prerelease %q is not alpha.N, beta.N or rc.N: %w", v.String(), v.PreRelease, ErrNotPEP440)
+		}
+		b.WriteString(phase + pre[1].String())
+	}
+	if v.Build != "" {
+		if strings.Trim(v.Build, "abcdefghijklmnopqrstuvwxyz0123456789.") != "" {
+			return "", fmt.Errorf("%s: build %q is not lowercase letters, digits and dots: %w", v.String(), v.Build, ErrNotPEP440)
+		}
+		b.WriteString("+" + v.Build)
+	}
+	return b.String(), nil
+}
+
+var pep440Re = regexp.MustCompile(`(?i)^v?(\d+(?:\.\d+){0,2})(?:[-_.]?(alpha|beta|preview|pre|rc|a|b|c)[-_.]?(\d*))?(?:\+([a-z0-9]+(?:[-_.][a-z0-9]+)*))?$`)
+
+// ParsePEP440 parses a PEP 440 version into SemVer, exactly reversing
+// PEP440: 1.2.0rc1 becomes 1.2.0-rc.1. Other spellings PEP 440 allows are
+// normalized the way pip does, so they do not read back as written: c, pre
+// and preview become rc, a missing phase number, minor or patch becomes 0
+// (1.2b is 1.2.0-beta.0), and the local label is lowercased with - and _
+// turned into dots. Epochs, post and dev releases and more than three
+// release numbers fail with ErrNotPEP440.
+func ParsePEP440(s string) (Version, error) {
+	m := pep440Re.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil {
+		return Version{}, fmt.Errorf("%q: %w", s, ErrNotPEP440)
+	}
+	release := strings.Split(m[1], ".")
+	for len(release) < 3 {
+		release = append(release, "0")
+	}
+	semver := strings.Join(release, ".")
+	if m[2] != "" {
+		n := m[3]
+		if n == "" {
+			n = "0"
+		}
+		semver += "-" + semverChannels[strings.ToLower(m[2])] + "." + n
+	}
+	if m[4] != "" {
+		semver += "+" + strings.NewReplacer("-", ".", "_", ".").Replace(strings.ToLower(m[4]))
+	}
+	v, err := Parse(semver)
+	if err != nil {
+		// leading zeros, or numbers too large for SemVer
+		return Version{}, fmt.Errorf("%q: %w", s, err)
+	}
+	return v, nil
+}
@@ -0,0 +1,66 @@
+package types
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestVersion_PEP440(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{"1.2.0", "1.2.0"},
+		{"1.2.0-rc.1", "1.2.0rc1"},
+		{"1.2.0-alpha.3", "1.2.0a3"},
+		{"1.2.0-beta.2", "1.2.0b2"},
+		{"1.2.0+ubuntu.1", "1.2.0+ubuntu.1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := MustParse(tt.in).PEP440()
+			if err != nil || got != tt.want {
+				t.Fatalf("PEP440() = %q, %v; want %q", got, err, tt.want)
+			}
+			back, err := ParsePEP440(got)
+			if err != nil {
+				t.Fatalf("ParsePEP440(%q): %v", got, err)
+			}
+			if back.String() != tt.in {
+				t.Fatalf("ParsePEP440(%q) = %s, want %s", got, back.String(), tt.in)
+			}
+		})
+	}
+
+	// these would not read back as written
+	for _, in := range []string{
+		"1.0.0-nightly.1", "1.0.0-rc.1.2", "1.0.0-rc.x", "1.1.0-alpha", "1.1.0-a.1", "1.0.0-RC.1",
+		"1.0.0+build-7", "1.0.0+Build.7",
+	} {
+		if got, err := MustParse(in).PEP440(); !errors.Is(err, ErrNotPEP440) {
+			t.Fatalf("PEP440(%s) = %q, %v; want ErrNotPEP440", in, got, err)
+		}
+	}
+}
+
+func TestParsePEP440(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{"1.2", "1.2.0"},
+		{"2", "2.0.0"},
+		{"v1.2.3", "1.2.3"},
+		{"1.2.0RC1", "1.2.0-rc.1"},
+		{"1.2.0.pre2", "1.2.0-rc.2"},
+		{"1.2.0-alpha.1", "1.2.0-alpha.1"},
+		{"1.2.0c1", "1.2.0-rc.1"},
+		{"1.2.0b", "1.2.0-beta.0"},
+		{"1.2.0+Local_7", "1.2.0+local.7"},
+	}
+	for _, tt := range tests {
+		if got, err := ParsePEP440(tt.in); err != nil || got.String() != tt.want {
+			t.Fatalf("ParsePEP440(%q) = %s, %v; want %s", tt.in, got.String(), err, tt.want)
+		}
+	}
+
+	for _, in := range []string{"", "1!1.0", "1.0.post1", "1.0.dev3", "1.2.3.4", "1.02.0", "banana"} {
+		if got, err := ParsePEP440(in); err == nil {
+			t.Fatalf("ParsePEP440(%q) = %s, want an error", in, got.String())
+		}
+	}
+}
@@ -0,0 +1,182 @@
+package types
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/dp1140a/semver/pkg/util"
+)
+
+// Identifier is a single dot-separated prerelease identifier. It is either
+// numeric (compared numerically) or alphanumeric (compared lexically).
+type Identifier struct {
+	str     string
+	num     uint64
+	numeric bool
+}
+
+// NumericIdentifier returns a numeric identifier with the value n.
+func NumericIdentifier(n uint64) Identifier {
+	return Identifier{str: strconv.FormatUint(n, 10), num: n, numeric: true}
+}
+
+// ParseIdentifier parses a single prerelease identifier.
+func ParseIdentifier(s string) (Identifier, error) {
+	id, pos, err := parseIdentifier(s)
+	if err != nil {
+		return Identifier{}, &ParseError{Input: s, Offset: pos, Err: err}
+	}
+	return id, nil
+}
+
+func parseIdentifier(s string) (Identifier, int, error) {
+	if s == "" {
+		return Identifier{}, 0, ErrEmptyIdentifier
+	}
+	for i := 0; i < len(s); i++ {
+		if !util.IsIdentChar(s[i]) {
+			return Identifier{}, i, ErrBadCharacter
+		}
+	}
+	if !isNumeric(s) {
+		return Identifier{str: s}, 0, nil
+	}
+	if len(s) > 1 && s[0] == '0' {
+		return Identifier{}, 0, ErrLeadingZero
+	}
+	n, err := parseInt(s)
+	if err != nil {
+		return Identifier{}, 0, err
+	}
+	return Identifier{str: s, num: n, numeric: true}, 0, nil
+}
+
+// IsNumeric reports whether the identifier consists only of digits.
+func (id Identifier) IsNumeric() bool {
+	return id.numeric
+}
+
+// Num returns the value of a numeric identifier, or 0 for an alphanumeric one.
+func (id Identifier) Num() uint64 {
+	return id.num
+}
+
+func (id Identifier) String() string {
+	return id.str
+}
+
+// Compare orders identifiers as SemVer 2.0.0 §11.4 requires: numeric
+// identifiers compare numerically and sort before alphanumeric ones, which
+// compare lexically in ASCII order. It shares compareIdentifier with
+// Version.Compare.
+func (id Identifier) Compare(o Identifier) int {
+	return compareIdentifier(id.str, o.str)
+}
+
+// PreRelease is the list of identifiers following the '-' in a version, as
+// returned by Version.Pre.
+// A nil or empty PreRelease means the version is a normal release.
+type PreRelease []Identifier
+
+// ParsePreRelease parses a dot-separated prerelease such as "rc.1".
+// The empty string yields a nil PreRelease.
+func ParsePreRelease(s string) (PreRelease, error) {
+	p, pos, err := parsePreRelease(s)
+	if err != nil {
+		return nil, &ParseError{Input: s, Offset: pos, Err: err}
+	}
+	return p, nil
+}
+
+// MustParsePreRelease is like ParsePreRelease but panics on error.
+func MustParsePreRelease(s string) PreRelease {
+	p, err := ParsePreRelease(s)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+// validatePreRelease checks s as parsePreRelease does without building the
+// identifiers, returning the offset of the first problem.
+func validatePreRelease(s string) (int, error) {
+	off := 0
+	for {
+		part, rest, more := strings.Cut(s, ".")
+		if _, pos, err := parseIdentifier(part); err != nil {
+			return off + pos, err
+		}
+		if !more {
+			return 0, nil
+		}
+		off += len(part) + 1
+		s = rest
+	}
+}
+
+func parsePreRelease(s string) (PreRelease, int, error) {
+	if s == "" {
+		return nil, 0, nil
+	}
+	parts := strings.Split(s, ".")
+	p := make(PreRelease, 0, len(parts))
+	off := 0
+	for _, part := range parts {
+		id, pos, err := parseIdentifier(part)
+		if err != nil {
+			return nil, off + pos, err
+		}
+		p = append(p, id)
+		off += len(part) + 1
+	}
+	return p, 0, nil
+}
+
+func (p PreRelease) String() string {
+	var sb strings.Builder
+	for i, id := range p {
+		if i > 0 {
+			sb.WriteByte('.')
+		}
+		sb.WriteString(id.str)
+	}
+	return sb.String()
+}
+
+// Compare orders two prereleases by comparing identifiers left to right.
+// An empty prerelease (a normal release) has the highest precedence, and
+// when all shared identifiers are equal the shorter list sorts first. It
+// shares comparePre with Version.Compare.
+func (p PreRelease) Compare(o PreRelease) int {
+	return comparePre(p.String(), o.String())
+}
+
+// Channel returns the leading alphanumeric identifier, such as "rc" in
+// "rc.1", or "" if the prerelease is empty or starts with a number.
+func (p PreRelease) Channel() string {
+	if len(p) == 0 || p[0].numeric {
+		return ""
+	}
+	return p[0].str
+}
+
+// Last returns the final identifier and false if the prerelease is empty.
+func (p PreRelease) Last() (Identifier, bool) {
+	if len(p) == 0 {
+		return Identifier{}, false
+	}
+	return p[len(p)-1], true
+}
+
+func (p PreRelease) MarshalText() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+func (p *PreRelease) UnmarshalText(text []byte) error {
+	parsed, err := ParsePreRelease(string(text))
+	if err != nil {
+		return err
+	}
+	*p = parsed
+	return nil
+}
@@ -0,0 +1,133 @@
+package types
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParsePreRelease_Identifiers(t *testing.T) {
+	p, err := ParsePreRelease("rc.1.x-y.007a")
+	if err != nil {
+		t.Fatalf("ParsePreRelease: %v", err)
+	}
+	want := []struct {
+		str     string
+		numeric bool
+		num     uint64
+	}{
+		{"rc", false, 0},
+		{"1", true, 1},
+		{"x-y", false, 0},
+		{"007a", false, 0},
+	}
+	if len(p) != len(want) {
+		t.Fatalf("expected %d identifiers, got %d", len(want), len(p))
+	}
+	for i, w := range want {
+		if p[i].String() != w.str || p[i].IsNumeric() != w.numeric || p[i].Num() != w.num {
+			t.Fatalf("identifier %d: got %q numeric=%v num=%d", i, p[i].String(), p[i].IsNumeric(), p[i].Num())
+		}
+	}
+	if got := p.String(); got != "rc.1.x-y.007a" {
+		t.Fatalf("round-trip: got %q", got)
+	}
+}
+
+func TestParsePreRelease_Empty(t *testing.T) {
+	p, err := ParsePreRelease("")
+	if err != nil || p != nil {
+		t.Fatalf("expected nil prerelease, got %v (%v)", p, err)
+	}
+}
+
+func TestParsePreRelease_Errors(t *testing.T) {
+	tests := []struct {
+		in     string
+		rule   error
+		offset int
+	}{
+		{"rc..1", ErrEmptyIdentifier, 3},
+		{".rc", ErrEmptyIdentifier, 0},
+		{"rc.01", ErrLeadingZero, 3},
+		{"rc_1", ErrBadCharacter, 2},
+		{"rc.18446744073709551616", ErrOverflow, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			_, err := ParsePreRelease(tt.in)
+			var pe *ParseError
+			if !errors.As(err, &pe) || !errors.Is(err, tt.rule) {
+				t.Fatalf("expected %v, got %v", tt.rule, err)
+			}
+			if pe.Offset != tt.offset {
+				t.Fatalf("offset = %d, want %d", pe.Offset, tt.offset)
+			}
+		})
+	}
+}
+
+func TestParse_PreReleaseOffsetInVersion(t *testing.T) {
+	_, err := Parse("v1.2.3-rc.18446744073709551616")
+	var pe *ParseError
+	if !errors.As(err, &pe) || !errors.Is(err, ErrOverflow) || pe.Offset != 10 {
+		t.Fatalf("expected overflow at offset 10, got %v", err)
+	}
+}
+
+func TestPreRelease_ChannelAndLast(t *testing.T) {
+	p := MustParsePreRelease("beta.3")
+	if got := p.Channel(); got != "beta" {
+		t.Fatalf("Channel() = %q, want beta", got)
+	}
+	last, ok := p.Last()
+	if !ok || !last.IsNumeric() || last.Num() != 3 {
+		t.Fatalf("Last() = %v, %v", last, ok)
+	}
+
+	if got := MustParsePreRelease("0.3.7").Channel(); got != "" {
+		t.Fatalf("numeric-leading Channel() = %q, want empty", got)
+	}
+	if _, ok := PreRelease(nil).Last(); ok {
+		t.Fatalf("Last() on empty prerelease should report false")
+	}
+}
+
+func TestNumericIdentifier(t *testing.T) {
+	id := NumericIdentifier(42)
+	if !id.IsNumeric() || id.Num() != 42 || id.String() != "42" {
+		t.Fatalf("unexpected identifier: %+v", id)
+	}
+	if id.Compare(MustParsePreRelease("42")[0]) != 0 {
+		t.Fatalf("expected equal to parsed 42")
+	}
+}
+
+func TestWithPre_RejectsInvalid(t *testing.T) {
+	v := MustParse("1.2.3-rc.1")
+	if _, err := v.WithPre("rc..2"); err == nil {
+		t.Fatalf("expected error")
+	}
+	if got := v.String(); got != "1.2.3-rc.1" {
+		t.Fatalf("version changed on invalid WithPre: %s", got)
+	}
+}
+
+func TestVersion_Pre(t *testing.T) {
+	p := MustParse("1.2.3-beta.11").Pre()
+	if len(p) != 2 || p.Channel() != "beta" || p[1].Num() != 11 {
+		t.Fatalf("unexpected identifiers: %v", p)
+	}
+	if p := MustParse("1.2.3").Pre(); p != nil {
+		t.Fatalf("expected nil for a release, got %v", p)
+	}
+	if p := (Version{PreRelease: "rc..1"}).Pre(); p != nil {
+		t.Fatalf("expected nil for an invalid prerelease, got %v", p)
+	}
+}
+
+func TestVersion_Comparable(t *testing.T) {
+	seen := map[Version]bool{MustParse("1.0.0-rc.1"): true}
+	if !seen[MustParse("v1.0.0-rc.1")] {
+		t.Fatalf("parsed versions should be usable as map keys")
+	}
+}
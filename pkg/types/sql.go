@@ -0,0 +1,61 @@
+package types
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strings"
+)
+
+// Scan implements sql.Scanner. The column must hold a version string; it is
+// parsed with Parse, so a malformed row is an error rather than a zero
+// Version. Scan into a sql.Null[Version] for nullable columns.
+func (v *Version) Scan(src any) error {
+	switch src := src.(type) {
+	case string:
+		return v.UnmarshalText([]byte(src))
+	case []byte:
+		return v.UnmarshalText(src)
+	case nil:
+		return fmt.Errorf("cannot scan NULL into %T", v)
+	}
+	return fmt.Errorf("cannot scan %T into %T", src, v)
+}
+
+// Value implements driver.Valuer, storing the canonical string.
+func (v Version) Value() (driver.Value, error) {
+	return v.String(), nil
+}
+
+// SortKey returns a string whose byte order follows SemVer precedence, for
+// use as an indexed column so that ORDER BY sorts versions correctly. Numbers
+// are zero-padded to 20 digits, numeric prerelease identifiers sort before
+// alphanumeric ones, and a release sorts after its prereleases. Build
+// metadata is not part of the key. Compare keys bytewise, e.g. with
+// COLLATE "C" in Postgres; locale-aware collations ignore the punctuation.
+// It fails if the prerelease is not valid.
+func (v Version) SortKey() (string, error) {
+	pre, err := ParsePreRelease(v.PreRelease)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "%020d.%020d.%020d", v.Major, v.Minor, v.Patch)
+	if len(pre) == 0 {
+		b.WriteByte('~')
+		return b.String(), nil
+	}
+	b.WriteByte('-')
+	for i, id := range pre {
+		if i > 0 {
+			// lower than any identifier character, so a shorter list sorts first
+			b.WriteByte('!')
+		}
+		if id.IsNumeric() {
+			fmt.Fprintf(&b, "0%020d", id.Num())
+		} else {
+			b.WriteByte('1')
+			b.WriteString(id.String())
+		}
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,78 @@
+package types
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestVersion_ScanValue(t *testing.T) {
+	v := MustParse("1.2.3-rc.1+build.5")
+	val, err := v.Value()
+	if err != nil || val != "1.2.3-rc.1+build.5" {
+		t.Fatalf("Value() = %v, %v", val, err)
+	}
+
+	for _, src := range []any{"1.2.3-rc.1+build.5", []byte("1.2.3-rc.1+build.5")} {
+		var got Version
+		if err := got.Scan(src); err != nil || got.String() != "1.2.3-rc.1+build.5" {
+			t.Fatalf("Scan(%T) = %s, %v", src, got.String(), err)
+		}
+	}
+
+	var got Version
+	if err := got.Scan("1.2"); !errors.Is(err, ErrMissingComponent) {
+		t.Fatalf("expected ErrMissingComponent, got %v", err)
+	}
+	for _, src := range []any{nil, int64(1)} {
+		if err := got.Scan(src); err == nil {
+			t.Fatalf("Scan(%v) should fail", src)
+		}
+	}
+}
+
+func TestVersion_SortKey(t *testing.T) {
+	// in ascending precedence
+	ordered := []string{
+		"0.9.0",
+		"1.0.0-0",
+		"1.0.0-2",
+		"1.0.0-10",
+		"1.0.0-10.1",
+		"1.0.0-a",
+		"1.0.0-a.1",
+		"1.0.0-a-",
+		"1.0.0-alpha",
+		"1.0.0-alpha.1",
+		"1.0.0-alpha.beta",
+		"1.0.0-beta",
+		"1.0.0-beta.2",
+		"1.0.0-beta.11",
+		"1.0.0-rc.1",
+		"1.0.0",
+		"1.0.1",
+		"1.2.0",
+		"1.10.0",
+		"18446744073709551615.0.0",
+	}
+	keys := make([]string, len(ordered))
+	for i, s := range ordered {
+		key, err := MustParse(s).SortKey()
+		if err != nil {
+			t.Fatalf("SortKey(%s): %v", s, err)
+		}
+		keys[i] = key
+	}
+	for i := 1; i < len(keys); i++ {
+		if keys[i-1] >= keys[i] {
+			t.Fatalf("key for %s does not sort before key for %s:\n%s\n%s", ordered[i-1], ordered[i], keys[i-1], keys[i])
+		}
+	}
+	a, _ := MustParse("1.0.0+a").SortKey()
+	b, _ := MustParse("1.0.0+b").SortKey()
+	if a != b {
+		t.Fatalf("build metadata should not affect the key")
+	}
+	if key, err := (Version{Major: 1, PreRelease: "rc..1"}).SortKey(); !errors.Is(err, ErrEmptyIdentifier) {
+		t.Fatalf("expected ErrEmptyIdentifier for an invalid prerelease, got %q, %v", key, err)
+	}
+}
@@ -3,16 +3,15 @@ package types
 import (
 	"encoding/json"
 	"fmt"
-	"regexp"
-	"strings"
+	"math"
 
 	"github.com/dp1140a/semver/pkg/util"
 )
 
 type Version struct {
-	Major      uint16
-	Minor      uint16
-	Patch      uint16
+	Major      uint64
+	Minor      uint64
+	Patch      uint64
 	PreRelease string
 	Build      string
 }
@@ -27,49 +26,130 @@ func NewVersion() Version {
 	}
 }
 
-var semverRE = regexp.MustCompile(util.SemVerRegex)
-
+// NewVersionFromString parses version and returns the zero Version if it is
+// not valid. Use Parse to find out why a string was rejected.
 func NewVersionFromString(version string) Version {
-	s := strings.TrimSpace(version)
-	if len(s) > 0 && (s[0] == 'v' || s[0] == 'V') {
-		s = s[1:]
-	}
-	matches := semverRE.FindStringSubmatch(s)
-	if matches == nil {
+	v, err := Parse(version)
+	if err != nil {
 		return Version{} // no fmt.Println side-effect
 	}
-	semver := Version{}
-	semver.Major = parseInt(matches[1])
-	semver.Minor = parseInt(matches[2])
-	semver.Patch = parseInt(matches[3])
-	if len(matches) > 4 {
-		semver.PreRelease = matches[4]
-	}
-	if len(matches) > 5 {
-		semver.Build = matches[5]
+	return v
+}
+
+// ErrOverflow is returned when a numeric identifier does not fit in a uint64,
+// either while parsing or when incrementing it. It is util.ErrOverflow.
+var ErrOverflow = util.ErrOverflow
+
+// IsPrerelease reports whether the version has a prerelease.
+func (v Version) IsPrerelease() bool {
+	return v.PreRelease != ""
+}
+
+// Pre returns the prerelease parsed into its identifiers, or nil if there is
+// none or it is not valid.
+func (v Version) Pre() PreRelease {
+	p, err := ParsePreRelease(v.PreRelease)
+	if err != nil {
+		return nil
 	}
-	return semver
+	return p
 }
 
-func (v *Version) IncrementMajor() {
+// IncrementMajor bumps the major version and resets everything below it.
+// The version is left unchanged if the major version cannot be incremented.
+func (v *Version) IncrementMajor() error {
+	if v.Major == math.MaxUint64 {
+		return fmt.Errorf("cannot increment major version %d: %w", v.Major, ErrOverflow)
+	}
 	v.Major++
 	v.Minor = 0
 	v.Patch = 0
 	v.PreRelease = ""
 	v.Build = ""
+	return nil
 }
 
-func (v *Version) IncrementMinor() {
+// IncrementMinor bumps the minor version and resets everything below it.
+// The version is left unchanged if the minor version cannot be incremented.
+func (v *Version) IncrementMinor() error {
+	if v.Minor == math.MaxUint64 {
+		return fmt.Errorf("cannot increment minor version %d: %w", v.Minor, ErrOverflow)
+	}
 	v.Minor++
 	v.Patch = 0
 	v.PreRelease = ""
 	v.Build = ""
+	return nil
 }
 
-func (v *Version) IncrementPatch() {
+// IncrementPatch bumps the patch version and clears prerelease and build.
+// The version is left unchanged if the patch version cannot be incremented.
+func (v *Version) IncrementPatch() error {
+	if v.Patch == math.MaxUint64 {
+		return fmt.Errorf("cannot increment patch version %d: %w", v.Patch, ErrOverflow)
+	}
 	v.Patch++
 	v.PreRelease = ""
 	v.Build = ""
+	return nil
+}
+
+// IncrementPre advances the prerelease the way `npm version prerelease` does.
+// A release gets its patch bumped and starts a new series ("1.4.0" becomes
+// "1.4.1-rc.0" with preid "rc"). A prerelease in the same series has its
+// rightmost numeric identifier incremented, or ".0" appended if it has none.
+// A different preid restarts the series at 0 without touching the core
+// ("1.4.0-beta.3" becomes "1.4.0-rc.0"). Build metadata is cleared. The
+// version is left unchanged if preid or its prerelease is not valid.
+func (v *Version) IncrementPre(preid string) error {
+	series, err := ParsePreRelease(preid)
+	if err != nil {
+		return err
+	}
+
+	pre, err := ParsePreRelease(v.PreRelease)
+	if err != nil {
+		return err
+	}
+
+	next := *v
+	next.Build = ""
+	switch {
+	case len(pre) == 0:
+		if err := next.IncrementPatch(); err != nil {
+			return err
+		}
+		next.PreRelease = append(series, NumericIdentifier(0)).String()
+	case !hasPrefix(pre, series):
+		next.PreRelease = append(series, NumericIdentifier(0)).String()
+	default:
+		i := len(pre) - 1
+		for i >= 0 && !pre[i].numeric {
+			i--
+		}
+		if i < 0 {
+			pre = append(pre, NumericIdentifier(0))
+		} else if pre[i].num == math.MaxUint64 {
+			return fmt.Errorf("cannot increment prerelease %s: %w", v.PreRelease, ErrOverflow)
+		} else {
+			pre[i] = NumericIdentifier(pre[i].num + 1)
+		}
+		next.PreRelease = pre.String()
+	}
+	*v = next
+	return nil
+}
+
+func hasPrefix(p, prefix PreRelease) bool {
+	if len(prefix) > len(p) {
+		return false
+	}
+	for i := range prefix {
+		if p[i].Compare(prefix[i]) != 0 {
+			return false
+		}
+	}
+	return true
 }
 
 func (v *Version) SetBuild(build string) {
@@ -80,12 +160,18 @@ func (v *Version) SetPre(pre string) {
 	v.PreRelease = pre
 }
 
-func parseInt(s string) uint16 {
-	num := 0
-	for _, c := range s {
-		num = num*10 + int(c-'0')
+// parseInt converts a string of ASCII digits, returning ErrOverflow rather
+// than wrapping when the value does not fit in a uint64.
+func parseInt(s string) (uint64, error) {
+	var num uint64
+	for i := 0; i < len(s); i++ {
+		d := uint64(s[i] - '0')
+		if num > (math.MaxUint64-d)/10 {
+			return 0, ErrOverflow
+		}
+		num = num*10 + d
 	}
-	return uint16(num)
+	return num, nil
 }
 
 func (v *Version) String() string {
@@ -99,8 +185,10 @@ func (v *Version) String() string {
 	return fmt.Sprintf("%v.%v.%v%v", v.Major, v.Minor, v.Patch, suffix)
 }
 
+// Json returns the version as an indented object with one field per
+// component. Use json.Marshal for the canonical string form.
 func (v *Version) Json() string {
-	json, err := json.MarshalIndent(v, "", "  ")
+	json, err := json.MarshalIndent((*fields)(v), "", "  ")
 	if err != nil {
 		return ""
 	}
@@ -2,6 +2,8 @@ package types
 
 import (
 	"encoding/json"
+	"errors"
+	"math"
 	"strings"
 	"testing"
 )
@@ -132,3 +134,122 @@ func TestJson_RoundTripShape(t *testing.T) {
 		t.Fatalf("round-trip mismatch: want %+v got %+v", v, got)
 	}
 }
+
+func TestNewVersionFromString_BeyondUint16(t *testing.T) {
+	v := NewVersionFromString("70000.65536.20251018")
+	if v.Major != 70000 || v.Minor != 65536 || v.Patch != 20251018 {
+		t.Fatalf("parsed wrong: %+v", v)
+	}
+	if got := v.String(); got != "70000.65536.20251018" {
+		t.Fatalf("expected 70000.65536.20251018, got %q", got)
+	}
+	if got := v.PrettyPrint(); !strings.Contains(got, "Major: 70000") {
+		t.Fatalf("unexpected pretty output: %s", got)
+	}
+	if got := v.Json(); !strings.Contains(got, `"Patch": 20251018`) {
+		t.Fatalf("unexpected JSON: %s", got)
+	}
+}
+
+func TestParse_Overflow(t *testing.T) {
+	in := "1.18446744073709551616.0"
+	_, err := Parse(in)
+	if !errors.Is(err, ErrOverflow) {
+		t.Fatalf("expected ErrOverflow, got %v", err)
+	}
+	var pe *ParseError
+	if !errors.As(err, &pe) || pe.Offset != 2 {
+		t.Fatalf("expected ParseError at offset 2, got %v", err)
+	}
+
+	v, err := Parse("18446744073709551615.0.0")
+	if err != nil || v.Major != math.MaxUint64 {
+		t.Fatalf("expected max uint64 major, got %+v (%v)", v, err)
+	}
+}
+
+func TestIncrement_OverflowLeavesVersionUnchanged(t *testing.T) {
+	max := uint64(math.MaxUint64)
+	tests := []struct {
+		name string
+		v    Version
+		inc  func(*Version) error
+	}{
+		{"major", Version{Major: max, Minor: 1, Patch: 1}, (*Version).IncrementMajor},
+		{"minor", Version{Major: 1, Minor: max, Patch: 1}, (*Version).IncrementMinor},
+		{"patch", Version{Major: 1, Minor: 1, Patch: max, PreRelease: "rc.1"}, (*Version).IncrementPatch},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := tt.v
+			if err := tt.inc(&tt.v); !errors.Is(err, ErrOverflow) {
+				t.Fatalf("expected ErrOverflow, got %v", err)
+			}
+			if tt.v != before {
+				t.Fatalf("version changed on overflow: %+v -> %+v", before, tt.v)
+			}
+		})
+	}
+}
+
+func TestIncrementPatch_Past65535(t *testing.T) {
+	v := Version{Major: 1, Minor: 0, Patch: 65535}
+	if err := v.IncrementPatch(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if v.Patch != 65536 {
+		t.Fatalf("expected 65536, got %d", v.Patch)
+	}
+}
+
+func TestIncrementPre(t *testing.T) {
+	tests := []struct {
+		in, preid, want string
+	}{
+		{"1.4.0-rc.1", "", "1.4.0-rc.2"},
+		{"1.4.0-rc.1", "rc", "1.4.0-rc.2"},
+		{"1.4.0", "rc", "1.4.1-rc.0"},
+		{"1.4.0", "", "1.4.1-0"},
+		{"1.4.0-beta.3", "rc", "1.4.0-rc.0"},
+		{"1.4.0-alpha", "", "1.4.0-alpha.0"},
+		{"1.4.0-rc", "rc", "1.4.0-rc.0"},
+		{"1.4.0-rc.1.hotfix", "", "1.4.0-rc.2.hotfix"},
+		{"1.4.0-rc.9+build.7", "", "1.4.0-rc.10"},
+		{"1.4.0-0", "", "1.4.0-1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in+"_"+tt.preid, func(t *testing.T) {
+			v := MustParse(tt.in)
+			if err := v.IncrementPre(tt.preid); err != nil {
+				t.Fatalf("IncrementPre: %v", err)
+			}
+			if got := v.String(); got != tt.want {
+				t.Fatalf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIncrementPre_DoesNotAliasPrerelease(t *testing.T) {
+	a := MustParse("1.0.0-rc.1")
+	b := a
+	if err := b.IncrementPre(""); err != nil {
+		t.Fatalf("IncrementPre: %v", err)
+	}
+	if got := a.String(); got != "1.0.0-rc.1" {
+		t.Fatalf("original version mutated: %s", got)
+	}
+}
+
+func TestIncrementPre_Errors(t *testing.T) {
+	v := MustParse("1.0.0-rc.18446744073709551615")
+	if err := v.IncrementPre(""); !errors.Is(err, ErrOverflow) {
+		t.Fatalf("expected ErrOverflow, got %v", err)
+	}
+	if err := v.IncrementPre("bad..id"); err == nil {
+		t.Fatalf("expected error for invalid preid")
+	}
+	if got := v.String(); got != "1.0.0-rc.18446744073709551615" {
+		t.Fatalf("version changed on error: %s", got)
+	}
+}
@@ -0,0 +1,41 @@
+package types
+
+// Versions is a collection of versions that implements sort.Interface,
+// ordering by SemVer precedence.
+type Versions []Version
+
+func (vs Versions) Len() int {
+	return len(vs)
+}
+
+func (vs Versions) Less(i, j int) bool {
+	return vs[i].LessThan(vs[j])
+}
+
+func (vs Versions) Swap(i, j int) {
+	vs[i], vs[j] = vs[j], vs[i]
+}
+
+// Max returns the index of the version with the highest precedence, or -1
+// if vs is empty. Ties go to the earliest version.
+func (vs Versions) Max() int {
+	best := -1
+	for i := range vs {
+		if best < 0 || vs[i].GreaterThan(vs[best]) {
+			best = i
+		}
+	}
+	return best
+}
+
+// Min returns the index of the version with the lowest precedence, or -1
+// if vs is empty. Ties go to the earliest version.
+func (vs Versions) Min() int {
+	best := -1
+	for i := range vs {
+		if best < 0 || vs[i].LessThan(vs[best]) {
+			best = i
+		}
+	}
+	return best
+}
@@ -0,0 +1,62 @@
+package types
+
+import (
+	"sort"
+	"testing"
+)
+
+func parseAll(t *testing.T, ss ...string) Versions {
+	t.Helper()
+	vs := make(Versions, len(ss))
+	for i, s := range ss {
+		vs[i] = MustParse(s)
+	}
+	return vs
+}
+
+func TestVersions_Sort(t *testing.T) {
+	vs := parseAll(t, "1.10.0", "1.2.0", "1.2.0-rc.1", "0.9.0", "1.2.0-beta")
+	sort.Sort(vs)
+	want := []string{"0.9.0", "1.2.0-beta", "1.2.0-rc.1", "1.2.0", "1.10.0"}
+	for i := range vs {
+		if got := vs[i].String(); got != want[i] {
+			t.Fatalf("index %d: got %s, want %s", i, got, want[i])
+		}
+	}
+}
+
+func TestVersions_StableForEqualPrecedence(t *testing.T) {
+	vs := parseAll(t, "1.0.0+b", "0.1.0", "1.0.0+a")
+	sort.Stable(vs)
+	if vs[1].Build != "b" || vs[2].Build != "a" {
+		t.Fatalf("stable sort reordered equal versions: %v", vs)
+	}
+}
+
+func TestVersions_MaxMin(t *testing.T) {
+	vs := parseAll(t, "1.2.0", "2.0.0-rc.1", "2.0.0+x", "0.1.0", "2.0.0+y")
+	if i := vs.Max(); i != 2 {
+		t.Fatalf("Max() = %d, want 2", i)
+	}
+	if i := vs.Min(); i != 3 {
+		t.Fatalf("Min() = %d, want 3", i)
+	}
+	if Versions(nil).Max() != -1 || Versions(nil).Min() != -1 {
+		t.Fatalf("expected -1 for empty Versions")
+	}
+}
+
+func TestSplitPrefix(t *testing.T) {
+	tests := []struct{ in, prefix, rest string }{
+		{"v1.2.3", "v", "1.2.3"},
+		{"V1.2.3", "V", "1.2.3"},
+		{"1.2.3", "", "1.2.3"},
+		{"", "", ""},
+	}
+	for _, tt := range tests {
+		p, r := SplitPrefix(tt.in)
+		if p != tt.prefix || r != tt.rest {
+			t.Fatalf("SplitPrefix(%q) = %q, %q", tt.in, p, r)
+		}
+	}
+}
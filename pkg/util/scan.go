@@ -0,0 +1,158 @@
+package util
+
+import "errors"
+
+// Rules a version string can break, as reported by ScanVersion. pkg/types
+// re-exports them, so errors.Is works against either name.
+var (
+	ErrEmpty            = errors.New("empty version string")
+	ErrMissingComponent = errors.New("expected major.minor.patch")
+	ErrLeadingZero      = errors.New("numeric identifier has a leading zero")
+	ErrEmptyIdentifier  = errors.New("empty identifier")
+	ErrBadCharacter     = errors.New("invalid character")
+	ErrOverflow         = errors.New("numeric identifier overflows uint64")
+)
+
+// Span is the byte range [Start, End) of one part of a scanned version.
+// Start is -1 when the part is absent.
+type Span struct {
+	Start, End int
+}
+
+// Spans locates the parts of a version string found by ScanVersion. The
+// prerelease and build spans exclude their '-' and '+' separators.
+type Spans struct {
+	Major, Minor, Patch Span
+	PreRelease, Build   Span
+}
+
+// ScanVersion checks s against SemVer 2.0.0 in a single pass without
+// allocating. It accepts exactly the strings SemVerRegex matches whose
+// numeric identifiers fit in a uint64; s is not trimmed and must not carry a
+// 'v' prefix. On success it returns where each part lies. Otherwise it
+// returns the byte offset of the first problem and the rule broken, one of
+// the Err* values.
+func ScanVersion(s string) (Spans, int, error) {
+	sp := Spans{PreRelease: Span{-1, -1}, Build: Span{-1, -1}}
+	if s == "" {
+		return sp, 0, ErrEmpty
+	}
+	i := 0
+
+	// major.minor.patch
+	for n, dst := range [3]*Span{&sp.Major, &sp.Minor, &sp.Patch} {
+		if n > 0 {
+			if i >= len(s) || s[i] == '-' || s[i] == '+' {
+				return sp, i, ErrMissingComponent
+			}
+			if s[i] != '.' {
+				return sp, i, ErrBadCharacter
+			}
+			i++
+		}
+		start := i
+		for i < len(s) && IsDigit(s[i]) {
+			i++
+		}
+		if i == start {
+			switch {
+			case i >= len(s):
+				return sp, i, ErrMissingComponent
+			case s[i] == '.':
+				return sp, i, ErrEmptyIdentifier
+			}
+			return sp, i, ErrBadCharacter
+		}
+		if s[start] == '0' && i-start > 1 {
+			return sp, start, ErrLeadingZero
+		}
+		if !fitsUint64(s[start:i]) {
+			return sp, start, ErrOverflow
+		}
+		*dst = Span{start, i}
+	}
+
+	// prerelease
+	if i < len(s) && s[i] == '-' {
+		i++
+		sp.PreRelease.Start = i
+		for {
+			start, numeric := i, true
+			for i < len(s) && IsIdentChar(s[i]) {
+				numeric = numeric && IsDigit(s[i])
+				i++
+			}
+			if i == start {
+				if i < len(s) && s[i] != '.' && s[i] != '+' {
+					return sp, i, ErrBadCharacter
+				}
+				return sp, i, ErrEmptyIdentifier
+			}
+			if numeric && s[start] == '0' && i-start > 1 {
+				return sp, start, ErrLeadingZero
+			}
+			if numeric && !fitsUint64(s[start:i]) {
+				return sp, start, ErrOverflow
+			}
+			if i >= len(s) || s[i] != '.' {
+				break
+			}
+			i++
+		}
+		sp.PreRelease.End = i
+	}
+
+	// build metadata
+	if i < len(s) && s[i] == '+' {
+		i++
+		if pos, err := ScanBuild(s[i:]); err != nil {
+			return sp, i + pos, err
+		}
+		sp.Build = Span{i, len(s)}
+		i = len(s)
+	}
+
+	if i < len(s) {
+		return sp, i, ErrBadCharacter
+	}
+	return sp, 0, nil
+}
+
+// maxUint64 is math.MaxUint64 in decimal.
+const maxUint64 = "18446744073709551615"
+
+// fitsUint64 reports whether the digits in s, which must not have leading
+// zeros, fit in a uint64.
+func fitsUint64(s string) bool {
+	return len(s) < len(maxUint64) || (len(s) == len(maxUint64) && s <= maxUint64)
+}
+
+// ScanBuild checks build metadata, without its '+': dot-separated, non-empty
+// identifiers of [0-9A-Za-z-], leading zeros allowed. It returns the offset
+// of the first problem and the rule broken.
+func ScanBuild(s string) (int, error) {
+	start := 0
+	for i := 0; i <= len(s); i++ {
+		switch {
+		case i == len(s) || s[i] == '.':
+			if i == start {
+				return i, ErrEmptyIdentifier
+			}
+			start = i + 1
+		case !IsIdentChar(s[i]):
+			return i, ErrBadCharacter
+		}
+	}
+	return 0, nil
+}
+
+// IsDigit reports whether c is an ASCII digit.
+func IsDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// IsIdentChar reports whether c may appear in a prerelease or build
+// identifier: an ASCII letter, digit or '-'.
+func IsIdentChar(c byte) bool {
+	return IsDigit(c) || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || c == '-'
+}
@@ -0,0 +1,153 @@
+package util
+
+import (
+	"errors"
+	"math/rand"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var semverRe = regexp.MustCompile(SemVerRegex)
+
+// seeds are valid and nearly valid versions; the differential test mutates
+// them as well as generating strings from scratch.
+var seeds = []string{
+	"0.0.0",
+	"1.2.3",
+	"10.20.30",
+	"1.2.3-0",
+	"1.2.3-rc.1",
+	"1.0.0-x.7.z.92",
+	"1.0.0-x-y-z.--",
+	"1.0.0-0a.00a.-0",
+	"1.0.0-alpha+001",
+	"1.0.0+21AF26D3----117B344092BD",
+	"1.0.0-beta+exp.sha.5114f85",
+	"01.2.3",
+	"1.2.3-01",
+	"1.2.3-rc..1",
+	"1.2.3+",
+	"1.2",
+}
+
+// checkAgainstRegex fails if ScanVersion and the spec regex disagree on s,
+// or if the spans differ from the regex's submatches. The regex has no notion
+// of overflow, so strings with numbers too large for a uint64 are skipped.
+func checkAgainstRegex(t *testing.T, s string) {
+	t.Helper()
+	m := semverRe.FindStringSubmatchIndex(s)
+	sp, _, err := ScanVersion(s)
+	if errors.Is(err, ErrOverflow) {
+		return
+	}
+	if (m != nil) != (err == nil) {
+		t.Fatalf("%q: regex match=%v, ScanVersion err=%v", s, m != nil, err)
+	}
+	if m == nil {
+		return
+	}
+	got := []Span{sp.Major, sp.Minor, sp.Patch, sp.PreRelease, sp.Build}
+	for i, span := range got {
+		if span.Start != m[2*i+2] || span.End != m[2*i+3] {
+			t.Fatalf("%q: span %d = %v, regex has [%d %d]", s, i, span, m[2*i+2], m[2*i+3])
+		}
+	}
+}
+
+func TestScanVersion_MatchesRegex(t *testing.T) {
+	const alphabet = "0123456789..--++az.Z-"
+	rng := rand.New(rand.NewSource(1))
+	for _, s := range seeds {
+		checkAgainstRegex(t, s)
+	}
+	// a quick sample; FuzzScanVersion explores further
+	for n := 0; n < 500; n++ {
+		var b []byte
+		if n%2 == 0 {
+			// mutate a seed: replace, insert or delete a few bytes
+			b = []byte(seeds[rng.Intn(len(seeds))])
+			for k := rng.Intn(3) + 1; k > 0; k-- {
+				c := alphabet[rng.Intn(len(alphabet))]
+				i := rng.Intn(len(b) + 1)
+				switch rng.Intn(3) {
+				case 0:
+					if i < len(b) {
+						b[i] = c
+					}
+				case 1:
+					b = append(b[:i], append([]byte{c}, b[i:]...)...)
+				default:
+					if i < len(b) {
+						b = append(b[:i], b[i+1:]...)
+					}
+				}
+			}
+		} else {
+			b = make([]byte, rng.Intn(16))
+			for i := range b {
+				b[i] = alphabet[rng.Intn(len(alphabet))]
+			}
+		}
+		checkAgainstRegex(t, string(b))
+	}
+}
+
+func TestScanVersion_Errors(t *testing.T) {
+	tests := []struct {
+		in     string
+		rule   error
+		offset int
+	}{
+		{"", ErrEmpty, 0},
+		{"1.2", ErrMissingComponent, 3},
+		{"1.2-rc", ErrMissingComponent, 3},
+		{"1..3", ErrEmptyIdentifier, 2},
+		{"01.2.3", ErrLeadingZero, 0},
+		{"1.2.3-rc.01", ErrLeadingZero, 9},
+		{"1.2.3-rc..1", ErrEmptyIdentifier, 9},
+		{"1.2.3+", ErrEmptyIdentifier, 6},
+		{"1.2.3+b_1", ErrBadCharacter, 7},
+		{"v1.2.3", ErrBadCharacter, 0},
+		{"1.2.3 ", ErrBadCharacter, 5},
+		{"1.18446744073709551616.0", ErrOverflow, 2},
+		{"1.2.3-rc.18446744073709551616", ErrOverflow, 9},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			_, offset, err := ScanVersion(tt.in)
+			assert.ErrorIs(t, err, tt.rule)
+			assert.Equal(t, tt.offset, offset)
+		})
+	}
+}
+
+func FuzzScanVersion(f *testing.F) {
+	for _, s := range seeds {
+		f.Add(s)
+	}
+	f.Fuzz(checkAgainstRegex)
+}
+
+func TestScanVersion_NoAllocs(t *testing.T) {
+	allocs := testing.AllocsPerRun(100, func() {
+		ScanVersion("1.0.0-beta.11+exp.sha.5114f85")
+		ValidVersionString(" 1.0.0-beta.11+exp.sha.5114f85\n")
+	})
+	assert.Zero(t, allocs)
+}
+
+func BenchmarkScanVersion(b *testing.B) {
+	b.ReportAllocs()
+	for b.Loop() {
+		ScanVersion("1.0.0-beta.11+exp.sha.5114f85")
+	}
+}
+
+func BenchmarkRegex(b *testing.B) {
+	b.ReportAllocs()
+	for b.Loop() {
+		semverRe.FindStringSubmatchIndex("1.0.0-beta.11+exp.sha.5114f85")
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"errors"
 	"os"
 	"path/filepath"
-	"regexp"
 	"strings"
 )
 
@@ -12,27 +11,36 @@ var NOT_GIT_MSG = `The current directory is not a git project.
 either change directories to a git project or first run:
 $ git init`
 
-const SemVerRegex = `^(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)(?:-((?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\+([0-9a-zA-Z-\.]+(?:\.[0-9a-zA-Z-]+)*))?$`
-
-var semverRe = regexp.MustCompile(SemVerRegex)
+// SemVerRegex is the regular expression from the SemVer 2.0.0 spec. It is
+// kept for reference; ScanVersion accepts exactly the same strings.
+const SemVerRegex = `^(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)(?:-((?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\+([0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?$`
 
 // CleanVersion trims trailing/leading whitespace (incl. \r\n)
 func CleanVersion(s string) string {
 	return strings.TrimSpace(s)
 }
 
+// ValidVersionString reports whether version, ignoring surrounding
+// whitespace, is a SemVer 2.0.0 version that types.Parse accepts: on top of
+// the spec's grammar every numeric identifier must fit in a uint64.
 func ValidVersionString(version string) bool {
-	version = CleanVersion(version) // <-- ignore trailing newline/CR/spaces
-	return semverRe.MatchString(version)
+	_, _, err := ScanVersion(CleanVersion(version)) // <-- ignore trailing newline/CR/spaces
+	return err == nil
 }
 
+// WriteVersionFile writes version to ./VERSION.
+//
+// Deprecated: use cli.WriteVersion, which writes to the selected store.
 func WriteVersionFile(version string) error {
 	// remove the println; add newline; or just migrate callers to cli.WriteVersion
 	return os.WriteFile("VERSION", []byte(version+"\n"), 0644)
 }
 
+// VersionFileExists reports whether cwd contains a VERSION file.
+//
+// Deprecated: use cli.ReadVersion, which reads from the selected store.
 func VersionFileExists(cwd string) bool {
-	_, err := os.Stat("VERSION")
+	_, err := os.Stat(filepath.Join(cwd, "VERSION"))
 	if err != nil {
 		return false
 	} else {
@@ -30,3 +30,31 @@ func TestValidVersionString(t *testing.T) {
 		})
 	}
 }
+
+func TestValidVersionString_Invalid(t *testing.T) {
+	tests := []string{
+		"",
+		"1.2",
+		"01.2.3",
+		"1.2.3-01",
+		"1.2.3-",
+		"1.2.3+",
+		"1.2.3+..",
+		"1.2.3+a..b",
+		"v1.2.3",
+	}
+	for _, tt := range tests {
+		t.Run(tt, func(t *testing.T) {
+			assert.False(t, ValidVersionString(tt), "Should Be False")
+		})
+	}
+}
+
+func TestValidVersionString_Overflow(t *testing.T) {
+	assert.True(t, ValidVersionString("18446744073709551615.0.0-18446744073709551615"))
+	assert.False(t, ValidVersionString("18446744073709551616.0.0"))
+	assert.False(t, ValidVersionString("1.99999999999999999999.0"))
+	assert.False(t, ValidVersionString("1.2.3-rc.18446744073709551616"))
+	assert.True(t, ValidVersionString("1.2.3-rc.18446744073709551616a"))
+	assert.True(t, ValidVersionString("1.2.3+99999999999999999999"))
+}